@@ -1,9 +1,18 @@
 package kube
 
 import (
+	"bytes"
+	"context"
+	"io"
+	"slices"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/cli-runtime/pkg/resource"
-	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/dynamic"
 )
 
 type Operation int
@@ -13,60 +22,421 @@ const (
 	OperationDelete
 )
 
+// DefaultFieldManager is the field manager used by server-side apply when none is given.
+const DefaultFieldManager = "kube"
+
+// ApplyOption configures Client.ApplyWithOptions and Client.DeleteWithOptions.
+type ApplyOption func(*applyOptions)
+
+type applyStrategy int
+
+const (
+	applyStrategyReplace applyStrategy = iota
+	applyStrategyServerSide
+	applyStrategyClientSide
+)
+
+type applyOptions struct {
+	strategy        applyStrategy
+	fieldManager    string
+	forceConflicts  bool
+	dryRun          bool
+	fileOrder       bool
+	continueOnError bool
+	load            []LoadOption
+	delete          []DeleteOption
+	namespaceMode   namespaceMode
+	namespace       string
+	strictNamespace bool
+	wait            bool
+	waitTimeout     time.Duration
+	pruneSetID      string
+	pruneKinds      []schema.GroupVersionKind
+}
+
+// WithServerSideApply sends objects as server-side apply patches owned by the given
+// field manager instead of replacing them. An empty fieldManager means DefaultFieldManager.
+func WithServerSideApply(fieldManager string) ApplyOption {
+	return func(o *applyOptions) {
+		o.strategy = applyStrategyServerSide
+		o.fieldManager = fieldManager
+	}
+}
+
+// WithClientSideApply is like 'kubectl apply' without '--server-side'. The applied
+// configuration is recorded in the kubectl.kubernetes.io/last-applied-configuration
+// annotation, and live objects are updated with a three-way merge patch computed from it,
+// so fields removed from the manifest are removed from the cluster while fields set by
+// others are kept.
+func WithClientSideApply() ApplyOption {
+	return func(o *applyOptions) {
+		o.strategy = applyStrategyClientSide
+	}
+}
+
+// WithForceConflicts makes server-side apply take ownership of fields owned by
+// other field managers instead of failing with a ConflictError.
+func WithForceConflicts() ApplyOption {
+	return func(o *applyOptions) {
+		o.forceConflicts = true
+	}
+}
+
+// WithDryRun is like '--dry-run=server', requests are sent with DryRun=All,
+// so they are validated by the server without being persisted.
+func WithDryRun() ApplyOption {
+	return func(o *applyOptions) {
+		o.dryRun = true
+	}
+}
+
+// WithFileOrder keeps objects in the order of the given files, instead of sorting them
+// by InstallOrder (or the reverse of it for deletion).
+func WithFileOrder() ApplyOption {
+	return func(o *applyOptions) {
+		o.fileOrder = true
+	}
+}
+
+// WithContinueOnError keeps going with the remaining objects when an object fails,
+// instead of stopping at the first failure. The failures are aggregated in the returned error.
+func WithContinueOnError() ApplyOption {
+	return func(o *applyOptions) {
+		o.continueOnError = true
+	}
+}
+
+// WithLoadOptions sets the LoadOption used to load objects from files, or from an io.Reader.
+func WithLoadOptions(opts ...LoadOption) ApplyOption {
+	return func(o *applyOptions) {
+		o.load = append(o.load, opts...)
+	}
+}
+
+// WithDeleteOptions sets the DeleteOption used to delete objects, e.g. WithPropagationPolicy.
+// With WithWaitForDeletion, each object is waited to be gone before deleting the next one.
+func WithDeleteOptions(opts ...DeleteOption) ApplyOption {
+	return func(o *applyOptions) {
+		o.delete = append(o.delete, opts...)
+	}
+}
+
+// WithDefaultNamespace sets the namespace of namespaced objects without metadata.namespace.
+// An empty namespace means the current namespace of the kubeconfig, or the namespace of
+// the service account if the Client is in cluster.
+func WithDefaultNamespace(namespace string) ApplyOption {
+	return func(o *applyOptions) {
+		o.namespaceMode = namespaceDefault
+		o.namespace = namespace
+	}
+}
+
+// WithNamespaceOverride sets the namespace of all namespaced objects, even if metadata.namespace is set.
+// An empty namespace means the current namespace, like WithDefaultNamespace.
+func WithNamespaceOverride(namespace string) ApplyOption {
+	return func(o *applyOptions) {
+		o.namespaceMode = namespaceOverride
+		o.namespace = namespace
+	}
+}
+
+// WithStrictNamespace rejects objects of cluster-scoped kinds that set metadata.namespace,
+// instead of ignoring their namespace.
+func WithStrictNamespace() ApplyOption {
+	return func(o *applyOptions) {
+		o.strictNamespace = true
+	}
+}
+
+// WithWait waits for the applied objects to be ready after Apply, until the given timeout expires.
+// A zero timeout means no timeout other than the deadline of the context. A *NotReadyError
+// reporting the objects that are not ready is returned if they are not ready in time.
+// See Client.WaitForReady for the readiness of each kind. It is ignored by Delete and WithDryRun.
+func WithWait(timeout time.Duration) ApplyOption {
+	return func(o *applyOptions) {
+		o.wait = true
+		o.waitTimeout = timeout
+	}
+}
+
+// WithPrune is like 'kubectl apply --prune', applied objects are labeled with PruneSetLabel
+// set to the given setID, then live objects labeled with the same setID that are not in the applied
+// objects are deleted, and reported with ActionPruned. The setID must be a valid label value.
+// Only objects of the given kinds are pruned, DefaultPruneKinds if none is given. Objects of
+// namespaced kinds are pruned only in the namespaces of the applied objects. Nothing is pruned
+// if an object fails, or if setID is empty. It is ignored by Delete.
+func WithPrune(setID string, kinds ...schema.GroupVersionKind) ApplyOption {
+	return func(o *applyOptions) {
+		o.pruneSetID = setID
+		o.pruneKinds = kinds
+	}
+}
+
+// dryRunAll returns the DryRun value of requests.
+func (o *applyOptions) dryRunAll() []string {
+	if o.dryRun {
+		return []string{metav1.DryRunAll}
+	}
+	return nil
+}
+
+// loadOptions returns the LoadOption set WithLoadOptions. Objects are decoded to unstructured.Unstructured,
+// so that the payload of an apply holds only the fields of the manifest.
+func (o *applyOptions) loadOptions() *loadOptions {
+	lo := newLoadOptions(o.load...)
+	lo.unstructured = true
+	return lo
+}
+
+func newApplyOptions(opts ...ApplyOption) *applyOptions {
+	o := &applyOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.strategy == applyStrategyServerSide && o.fieldManager == "" {
+		o.fieldManager = DefaultFieldManager
+	}
+	if o.pruneSetID != "" && len(o.pruneKinds) == 0 {
+		o.pruneKinds = DefaultPruneKinds
+	}
+	return o
+}
+
 // Apply is like 'kubectl apply -f'.
 // references:
 // - https://github.com/kubernetes/client-go/issues/193
 // - https://stackoverflow.com/questions/58783939/using-client-go-to-kubectl-apply-against-the-kubernetes-api-directly-with-mult
 func (c *Client) Apply(files ...string) error {
-	return c.execute(OperationApply, files...)
+	_, err := c.executeFiles(context.TODO(), OperationApply, files, newApplyOptions())
+	return err
+}
+
+// ApplyWithOptions is like Apply, but with the given ApplyOption.
+// e.g. WithServerSideApply makes it like 'kubectl apply --server-side -f'.
+func (c *Client) ApplyWithOptions(ctx context.Context, files []string, opts ...ApplyOption) error {
+	_, err := c.executeFiles(ctx, OperationApply, files, newApplyOptions(opts...))
+	return err
+}
+
+// ApplyWithResults is like ApplyWithOptions, but also returns the Result of each object.
+func (c *Client) ApplyWithResults(ctx context.Context, files []string, opts ...ApplyOption) (Results, error) {
+	return c.executeFiles(ctx, OperationApply, files, newApplyOptions(opts...))
+}
+
+// ApplyReader is like 'kubectl apply -f -', the objects are read from the given io.Reader.
+func (c *Client) ApplyReader(ctx context.Context, r io.Reader, opts ...ApplyOption) (Results, error) {
+	return c.executeReader(ctx, OperationApply, r, newApplyOptions(opts...))
+}
+
+// ApplyBytes is like ApplyReader, but with the given YAML or JSON content.
+func (c *Client) ApplyBytes(ctx context.Context, content []byte, opts ...ApplyOption) (Results, error) {
+	return c.executeReader(ctx, OperationApply, bytes.NewReader(content), newApplyOptions(opts...))
+}
+
+// ApplyObjects is like ApplyWithResults, but with the given objects. Typed objects are applied
+// with all their fields, including zero values, e.g. the targetPort of a Service port, pass
+// unstructured.Unstructured objects to apply only the fields that are set.
+func (c *Client) ApplyObjects(ctx context.Context, objs []runtime.Object, opts ...ApplyOption) (Results, error) {
+	return c.execute(ctx, OperationApply, slices.Clone(objs), newApplyOptions(opts...))
 }
 
 // Delete is like 'kubectl delete -f'.
 func (c *Client) Delete(files ...string) error {
-	return c.execute(OperationDelete, files...)
+	_, err := c.executeFiles(context.TODO(), OperationDelete, files, newApplyOptions())
+	return err
+}
+
+// DeleteWithOptions is like Delete, but with the given ApplyOption.
+func (c *Client) DeleteWithOptions(ctx context.Context, files []string, opts ...ApplyOption) error {
+	_, err := c.executeFiles(ctx, OperationDelete, files, newApplyOptions(opts...))
+	return err
+}
+
+// DeleteWithResults is like DeleteWithOptions, but also returns the Result of each object.
+func (c *Client) DeleteWithResults(ctx context.Context, files []string, opts ...ApplyOption) (Results, error) {
+	return c.executeFiles(ctx, OperationDelete, files, newApplyOptions(opts...))
+}
+
+// DeleteReader is like 'kubectl delete -f -', the objects are read from the given io.Reader.
+func (c *Client) DeleteReader(ctx context.Context, r io.Reader, opts ...ApplyOption) (Results, error) {
+	return c.executeReader(ctx, OperationDelete, r, newApplyOptions(opts...))
+}
+
+// DeleteBytes is like DeleteReader, but with the given YAML or JSON content.
+func (c *Client) DeleteBytes(ctx context.Context, content []byte, opts ...ApplyOption) (Results, error) {
+	return c.executeReader(ctx, OperationDelete, bytes.NewReader(content), newApplyOptions(opts...))
+}
+
+// DeleteObjects is like DeleteWithResults, but with the given objects.
+func (c *Client) DeleteObjects(ctx context.Context, objs []runtime.Object, opts ...ApplyOption) (Results, error) {
+	return c.execute(ctx, OperationDelete, slices.Clone(objs), newApplyOptions(opts...))
+}
+
+func (c *Client) executeFiles(ctx context.Context, op Operation, files []string, opts *applyOptions) (Results, error) {
+	objs, err := loadObjects(files, opts.loadOptions())
+	if err != nil {
+		return nil, err
+	}
+	return c.execute(ctx, op, objs, opts)
 }
 
-func (c *Client) execute(op Operation, files ...string) error {
-	objs, err := GetObjects(files...)
+func (c *Client) executeReader(ctx context.Context, op Operation, r io.Reader, opts *applyOptions) (Results, error) {
+	objs, err := readerObjects(r, readerSource, opts.loadOptions())
 	if err != nil {
-		return err
+		return nil, err
+	}
+	return c.execute(ctx, op, objs, opts)
+}
+
+func (c *Client) execute(ctx context.Context, op Operation, objs []runtime.Object, opts *applyOptions) (Results, error) {
+	if op == OperationApply {
+		if err := validatePruneSetID(opts.pruneSetID); err != nil {
+			return nil, err
+		}
+	}
+	if !opts.fileOrder {
+		sortObjects(objs, op)
 	}
-	// Create a REST mapper that tracks information about the available resources in the cluster.
-	groupResources, err := restmapper.GetAPIGroupResources(c.client.Discovery())
+	if err := c.resolveNamespace(opts); err != nil {
+		return nil, err
+	}
+	mapper, err := c.RESTMapper()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	mapper := restmapper.NewDiscoveryRESTMapper(groupResources)
 
-	for i := range objs {
-		// Get some metadata needed to make the REST request.
-		gvk := objs[i].GetObjectKind().GroupVersionKind()
-		gk := schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind}
-		mapping, err := mapper.RESTMapping(gk, gvk.Version)
+	results, err := executeEach(ctx, objs, opts, func(obj runtime.Object) Result {
+		return c.executeObject(ctx, mapper, op, obj, opts)
+	})
+	if err != nil {
+		return results, err
+	}
+	if op == OperationApply && opts.pruneSetID != "" {
+		pruned, err := c.prune(ctx, mapper, results, opts)
+		results = append(results, pruned...)
 		if err != nil {
-			return err
+			return results, err
 		}
-		namespace, name, err := retrievesMetaFromObject(objs[i])
-		if err != nil {
-			return err
+	}
+	if op == OperationApply && opts.wait && !opts.dryRun {
+		_, err = c.waitForReady(ctx, mapper, readyObjects(results), opts, opts.waitTimeout)
+	}
+	return results, err
+}
+
+// executeEach calls fn for each of the given objects, and returns the results. The remaining objects
+// are skipped after a failure unless WithContinueOnError. The error is the aggregated errors of failures.
+func executeEach(ctx context.Context, objs []runtime.Object, opts *applyOptions, fn func(obj runtime.Object) Result) (Results, error) {
+	results := make(Results, 0, len(objs))
+	for i := range objs {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		result := fn(objs[i])
+		results = append(results, result)
+		if result.Err != nil && !opts.continueOnError {
+			for _, obj := range objs[i+1:] {
+				skipped := newResult(obj)
+				skipped.Action = ActionSkipped
+				results = append(results, skipped)
+			}
+			break
 		}
-		cli, err := c.ResourceClient(mapping.GroupVersionKind.GroupVersion())
+	}
+	return results, results.Err()
+}
+
+func (c *Client) executeObject(ctx context.Context, mapper meta.ResettableRESTMapper, op Operation, obj runtime.Object, opts *applyOptions) Result {
+	result := newResult(obj)
+	desired, err := manifestObject(obj)
+	if err != nil {
+		return result.failed(err)
+	}
+	ri, err := c.resourceInterface(mapper, desired, opts)
+	if err != nil {
+		return result.failed(err)
+	}
+	result.Namespace = desired.GetNamespace()
+	if op == OperationApply {
+		setPruneLabel(desired, opts)
+	}
+	live, err := getObject(ctx, ri, result.Name)
+	if err != nil {
+		return result.failed(err)
+	}
+
+	switch op {
+	case OperationApply:
+		applied, err := applyObjectWithOptions(ctx, ri, desired, live, opts)
 		if err != nil {
-			return err
+			return result.failed(err)
 		}
-		helper := resource.NewHelper(cli, mapping)
-		switch op {
-		case OperationApply:
-			err = applyObject(helper, namespace, name, objs[i])
-			if err != nil {
-				return err
+		if result.Action, err = appliedAction(live, applied); err != nil {
+			return result.failed(err)
+		}
+		// Objects of a newly created CustomResourceDefinition can be mapped
+		// only after it is established and the resources are rediscovered.
+		if isCRD(obj) && !opts.dryRun {
+			if err = waitForCRDEstablished(ctx, ri, result.Name); err != nil {
+				return result.failed(err)
 			}
-		case OperationDelete:
-			err = deleteObject(helper, namespace, name)
+			mapper.Reset()
+		}
+	case OperationDelete:
+		result.Action = ActionUnchanged
+		if live == nil {
+			break
+		}
+		do := newDeleteOptions(opts.delete...)
+		do.DryRun = opts.dryRunAll()
+		if err = ri.Delete(ctx, result.Name, do.DeleteOptions); err != nil {
+			return result.failed(err)
+		}
+		if do.wait && !opts.dryRun {
+			err = waitForDeletion(ctx, result.Name, live.GetUID(), do.waitTimeout, func(ctx context.Context) (any, error) {
+				return ri.Get(ctx, result.Name, metav1.GetOptions{})
+			})
 			if err != nil {
-				return err
+				return result.failed(err)
 			}
 		}
+		result.Action = ActionDeleted
+	}
+	return result
+}
+
+// resourceInterface returns a dynamic.ResourceInterface for the REST mapping of the given object,
+// the namespace of the object is set according to the scope of the mapping and the given options.
+// The resources are rediscovered once if the kind of the object is not found.
+func (c *Client) resourceInterface(mapper meta.ResettableRESTMapper, obj *unstructured.Unstructured, opts *applyOptions) (dynamic.ResourceInterface, error) {
+	// Get some metadata needed to make the REST request.
+	mapping, err := c.restMapping(mapper, obj.GroupVersionKind())
+	if err != nil {
+		return nil, err
+	}
+	namespaced := mapping.Scope.Name() == meta.RESTScopeNameNamespace
+	if err = setNamespace(obj, namespaced, opts); err != nil {
+		return nil, err
+	}
+	dyn, err := c.DialDynamic()
+	if err != nil {
+		return nil, err
+	}
+	if namespaced {
+		return dyn.Resource(mapping.Resource).Namespace(obj.GetNamespace()), nil
+	}
+	return dyn.Resource(mapping.Resource), nil
+}
+
+// applyObjectWithOptions applies the given object with the apply strategy of the given options,
+// and returns the object returned by the server. live is the current object, nil if not found.
+func applyObjectWithOptions(ctx context.Context, ri dynamic.ResourceInterface, obj, live *unstructured.Unstructured, opts *applyOptions) (*unstructured.Unstructured, error) {
+	switch opts.strategy {
+	case applyStrategyServerSide:
+		return serverSideApplyObject(ctx, ri, obj, opts)
+	case applyStrategyClientSide:
+		return clientSideApplyObject(ctx, ri, obj, live, opts)
+	default:
+		return applyObject(ctx, ri, obj, live, opts)
 	}
-	return nil
 }
@@ -1,12 +1,14 @@
 package kube
 
 import (
+	"bytes"
 	"context"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -77,3 +79,117 @@ func TestClientApply(t *testing.T) {
 	err = mockcli.Delete("testdata/content-create.yaml", "testdata/content-apply.yaml", "testdata/content-apply2.yaml")
 	require.NoError(t, err)
 }
+
+func TestClientApplyServerSide(t *testing.T) {
+	k8s, err := mockcli.Dial()
+	require.NoError(t, err)
+
+	files := []string{"testdata/content-apply.yaml"}
+	err = mockcli.DeleteWithOptions(context.TODO(), files, WithDeleteOptions(WithWaitForDeletion(time.Minute)))
+	require.NoError(t, err)
+
+	t.Run("apply:server-side", func(t *testing.T) {
+		err = mockcli.ApplyWithOptions(context.TODO(), files, WithServerSideApply("kube-test"))
+		require.NoError(t, err)
+
+		d, err := k8s.CoreV1().ConfigMaps("default").Get(context.TODO(), "kube-cm1", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"TESTDATA": "kube-cm1-update"}, d.Data)
+		found := false
+		for _, mf := range d.ManagedFields {
+			if mf.Manager == "kube-test" && mf.Operation == metav1.ManagedFieldsOperationApply {
+				found = true
+			}
+		}
+		require.True(t, found)
+	})
+
+	t.Run("apply:server-side:conflict", func(t *testing.T) {
+		err = mockcli.ApplyWithOptions(context.TODO(), []string{"testdata/content-apply2.yaml"}, WithServerSideApply("kube-test-other"))
+		require.True(t, IsConflictError(err))
+
+		err = mockcli.ApplyWithOptions(context.TODO(), []string{"testdata/content-apply2.yaml"},
+			WithServerSideApply("kube-test-other"), WithForceConflicts())
+		require.NoError(t, err)
+		d, err := k8s.CoreV1().ConfigMaps("default").Get(context.TODO(), "kube-cm1", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"TESTDATA": "kube-cm1-multi"}, d.Data)
+	})
+
+	// clean
+	err = mockcli.Delete("testdata/content-apply.yaml", "testdata/content-apply2.yaml")
+	require.NoError(t, err)
+}
+
+func TestClientApplyClientSide(t *testing.T) {
+	k8s, err := mockcli.Dial()
+	require.NoError(t, err)
+
+	// clean
+	err = mockcli.Delete("testdata/content-apply2.yaml")
+	require.NoError(t, err)
+
+	t.Run("apply:client-side:create", func(t *testing.T) {
+		err = mockcli.ApplyWithOptions(context.TODO(), []string{"testdata/content-apply2.yaml"}, WithClientSideApply())
+		require.NoError(t, err)
+		d, err := k8s.CoreV1().ConfigMaps("default").Get(context.TODO(), "kube-cm2", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Contains(t, d.Annotations, corev1.LastAppliedConfigAnnotation)
+	})
+
+	t.Run("apply:client-side:remove-field", func(t *testing.T) {
+		// add a key out-of-band
+		_, err = mockcli.PatchConfigMap(context.TODO(), "default", "kube-cm2", []byte(`{"data":{"OUTOFBAND":"kept"}}`))
+		require.NoError(t, err)
+
+		// TESTDATA2 is not in content-apply.yaml
+		err = mockcli.ApplyWithOptions(context.TODO(), []string{"testdata/content-apply.yaml"}, WithClientSideApply())
+		require.NoError(t, err)
+		d, err := k8s.CoreV1().ConfigMaps("default").Get(context.TODO(), "kube-cm2", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"TESTDATA": "kube-cm2", "OUTOFBAND": "kept"}, d.Data)
+	})
+
+	// clean
+	err = mockcli.Delete("testdata/content-apply.yaml", "testdata/content-apply2.yaml")
+	require.NoError(t, err)
+}
+
+func TestClientApplyBytes(t *testing.T) {
+	content := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: kube-cm-bytes
+  namespace: default
+data:
+  TESTDATA: "kube-cm-bytes"
+`)
+
+	t.Run("apply:bytes", func(t *testing.T) {
+		results, err := mockcli.ApplyBytes(context.TODO(), content)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		d, err := mockcli.GetConfigMap(context.TODO(), "default", "kube-cm-bytes")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"TESTDATA": "kube-cm-bytes"}, d.Data)
+	})
+
+	t.Run("apply:objects", func(t *testing.T) {
+		objs, err := LoadObjects([]string{"testdata/manifests"}, WithRecursive())
+		require.NoError(t, err)
+		results, err := mockcli.ApplyObjects(context.TODO(), objs)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		_, err = mockcli.GetSecret(context.TODO(), "default", "kube-manifests-secret")
+		require.NoError(t, err)
+	})
+
+	t.Run("delete:reader", func(t *testing.T) {
+		results, err := mockcli.DeleteReader(context.TODO(), bytes.NewReader(content))
+		require.NoError(t, err)
+		require.Equal(t, ActionDeleted, results[0].Action)
+
+		err = mockcli.DeleteWithOptions(context.TODO(), []string{"testdata/manifests"}, WithLoadOptions(WithRecursive()))
+		require.NoError(t, err)
+	})
+}
@@ -2,16 +2,26 @@ package kube
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
+	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/httpstream"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	clischeme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/remotecommand"
 	"k8s.io/metrics/pkg/client/clientset/versioned"
 )
@@ -27,9 +37,21 @@ var ErrorMissingNamespace = errors.New("missing namespace")
 type Client struct {
 	client    kubernetes.Interface
 	metrics   *versioned.Clientset
+	dynamic   dynamic.Interface
+	mapper    meta.ResettableRESTMapper
 	cfg       *Config
 	proxy     func(request *http.Request) (*url.URL, error)
+	protocol  ExecProtocol
 	inCluster bool
+
+	// resetMu guards the time of the last reset of the mapper caused by a NoMatch error.
+	resetMu       sync.Mutex
+	mapperResetAt time.Time
+
+	// mu guards the cached informer factories.
+	mu               sync.Mutex
+	informers        map[informerOptions]informers.SharedInformerFactory
+	dynamicInformers map[informerOptions]dynamicinformer.DynamicSharedInformerFactory
 }
 
 // New returns a new Client for the given Config.
@@ -55,6 +77,13 @@ func (c *Client) WithProxy(fn func(request *http.Request) (*url.URL, error)) *Cl
 	return c
 }
 
+// WithExecProtocol sets the streaming protocol used by Exec, Upload and Download.
+// Defaults to ExecProtocolFallback.
+func (c *Client) WithExecProtocol(protocol ExecProtocol) *Client {
+	c.protocol = protocol
+	return c
+}
+
 // DialMetrics returns a new versioned.Clientset to the metrics server.
 func (c *Client) DialMetrics() (*versioned.Clientset, error) {
 	if c.metrics != nil {
@@ -85,6 +114,38 @@ func (c *Client) Dial() (kubernetes.Interface, error) {
 	return c.client, nil
 }
 
+// DialDynamic returns a new dynamic.Interface to the kubernetes server.
+func (c *Client) DialDynamic() (dynamic.Interface, error) {
+	if c.dynamic != nil {
+		return c.dynamic, nil
+	}
+	cfg, err := c.RestConfig()
+	if err != nil {
+		return nil, err
+	}
+	if c.dynamic, err = dynamic.NewForConfig(cfg); err != nil {
+		return nil, err
+	}
+	return c.dynamic, nil
+}
+
+// RESTMapper returns a REST mapper backed by the cached discovery information of the kubernetes server.
+// The resources are discovered on first use, and rediscovered after Reset. Resource shortcuts
+// (e.g. "deploy" for "deployments") are expanded.
+func (c *Client) RESTMapper() (meta.ResettableRESTMapper, error) {
+	if c.mapper != nil {
+		return c.mapper, nil
+	}
+	client, err := c.Dial()
+	if err != nil {
+		return nil, err
+	}
+	cached := memory.NewMemCacheClient(client.Discovery())
+	deferred := restmapper.NewDeferredDiscoveryRESTMapper(cached)
+	c.mapper = restmapper.NewShortcutExpander(deferred, cached, nil).(meta.ResettableRESTMapper)
+	return c.mapper, nil
+}
+
 // RestConfig returns a complete rest client config.
 func (c *Client) RestConfig() (*rest.Config, error) {
 	var (
@@ -135,16 +196,36 @@ func (c *Client) RemoteExecRequest(method ExecRequestMethod, pod, namespace stri
 		VersionedParams(options, clischeme.ParameterCodec)
 }
 
-// RemoteExecutor returns a client for the given schema.GroupVersion.
+// RemoteExecutor returns a remotecommand.Executor for the given request, using the ExecProtocol of the client.
 func (c *Client) RemoteExecutor(req *rest.Request) (remotecommand.Executor, error) {
 	restcfg, err := c.RestConfig()
 	if err != nil {
 		return nil, err
 	}
+	return newRemoteExecutor(restcfg, c.protocol, req.URL())
+}
 
-	executor, err := remotecommand.NewSPDYExecutor(restcfg, "POST", req.URL())
-	if err != nil {
-		return nil, err
+// newRemoteExecutor returns a remotecommand.Executor for the given protocol.
+func newRemoteExecutor(cfg *rest.Config, protocol ExecProtocol, u *url.URL) (remotecommand.Executor, error) {
+	switch protocol {
+	case ExecProtocolSPDY:
+		return remotecommand.NewSPDYExecutor(cfg, "POST", u)
+	case ExecProtocolWebSocket:
+		// WebSocket upgrade requests must be GET requests.
+		return remotecommand.NewWebSocketExecutor(cfg, "GET", u.String())
+	case "", ExecProtocolFallback:
+		ws, err := remotecommand.NewWebSocketExecutor(cfg, "GET", u.String())
+		if err != nil {
+			return nil, err
+		}
+		spdy, err := remotecommand.NewSPDYExecutor(cfg, "POST", u)
+		if err != nil {
+			return nil, err
+		}
+		return remotecommand.NewFallbackExecutor(ws, spdy, func(err error) bool {
+			return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+		})
+	default:
+		return nil, fmt.Errorf("unsupported exec protocol %q", protocol)
 	}
-	return executor, nil
 }
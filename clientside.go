@@ -0,0 +1,107 @@
+package kube
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
+	"k8s.io/apimachinery/pkg/util/mergepatch"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// emptyPatch is the patch computed for objects without any changes.
+const emptyPatch = "{}"
+
+// clientSideApplyObject is like 'kubectl apply' without '--server-side'.
+// references:
+// - https://kubernetes.io/docs/tasks/manage-kubernetes-objects/declarative-config/#merge-patch-calculation
+func clientSideApplyObject(ctx context.Context, ri dynamic.ResourceInterface, obj, live *unstructured.Unstructured, opts *applyOptions) (*unstructured.Unstructured, error) {
+	desired, modified, err := modifiedConfiguration(obj)
+	if err != nil {
+		return nil, err
+	}
+	if live == nil {
+		return ri.Create(ctx, desired, metav1.CreateOptions{DryRun: opts.dryRunAll()})
+	}
+	patch, pt, err := threeWayMergePatch(obj.GroupVersionKind(), live, modified)
+	if err != nil {
+		return nil, err
+	}
+	if string(patch) == emptyPatch {
+		return live, nil
+	}
+	return ri.Patch(ctx, obj.GetName(), pt, patch, metav1.PatchOptions{DryRun: opts.dryRunAll()})
+}
+
+// modifiedConfiguration returns a copy of the given manifest with the
+// last-applied-configuration annotation set, and its JSON encoding.
+// Like kubectl, the annotation holds the encoding of the manifest as it is decoded,
+// without the annotation itself, so that the fields it does not set, e.g. the targetPort
+// of a Service port, are not recorded and kubectl apply can be used interchangeably.
+func modifiedConfiguration(obj *unstructured.Unstructured) (*unstructured.Unstructured, []byte, error) {
+	u := obj.DeepCopy()
+	annots := u.GetAnnotations()
+	if annots == nil {
+		annots = map[string]string{}
+	}
+	delete(annots, corev1.LastAppliedConfigAnnotation)
+	u.SetAnnotations(annots)
+	if len(annots) == 0 {
+		unstructured.RemoveNestedField(u.Object, "metadata", "annotations")
+	}
+	applied, err := json.Marshal(u.Object)
+	if err != nil {
+		return nil, nil, err
+	}
+	annots[corev1.LastAppliedConfigAnnotation] = string(applied)
+	u.SetAnnotations(annots)
+	modified, err := json.Marshal(u.Object)
+	if err != nil {
+		return nil, nil, err
+	}
+	return u, modified, nil
+}
+
+// threeWayMergePatch computes the patch that updates the live object to the modified configuration,
+// using the last-applied-configuration annotation of the live object as the original configuration.
+// A strategic merge patch is used for kinds registered in the scheme, a JSON merge patch otherwise.
+func threeWayMergePatch(gvk schema.GroupVersionKind, live runtime.Object, modified []byte) ([]byte, types.PatchType, error) {
+	current, err := json.Marshal(live)
+	if err != nil {
+		return nil, "", err
+	}
+	var original []byte
+	if u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(live); err == nil {
+		if annot, found, _ := unstructured.NestedString(u, "metadata", "annotations", corev1.LastAppliedConfigAnnotation); found {
+			original = []byte(annot)
+		}
+	}
+
+	versioned, err := scheme.Scheme.New(gvk)
+	switch {
+	case runtime.IsNotRegisteredError(err):
+		preconditions := []mergepatch.PreconditionFunc{
+			mergepatch.RequireKeyUnchanged("apiVersion"),
+			mergepatch.RequireKeyUnchanged("kind"),
+			mergepatch.RequireMetadataKeyUnchanged("name"),
+		}
+		patch, err := jsonmergepatch.CreateThreeWayJSONMergePatch(original, modified, current, preconditions...)
+		return patch, types.MergePatchType, err
+	case err != nil:
+		return nil, "", err
+	}
+	meta, err := strategicpatch.NewPatchMetaFromStruct(versioned)
+	if err != nil {
+		return nil, "", err
+	}
+	patch, err := strategicpatch.CreateThreeWayMergePatch(original, modified, current, meta, true)
+	return patch, types.StrategicMergePatchType, err
+}
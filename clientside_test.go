@@ -0,0 +1,123 @@
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestThreeWayMergePatch(t *testing.T) {
+	newcm := func(data map[string]string) *unstructured.Unstructured {
+		cm, err := manifestObject(&corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-cm", Namespace: "default"},
+			Data:       data,
+		})
+		require.NoError(t, err)
+		return cm
+	}
+
+	t.Run("typed", func(t *testing.T) {
+		// last applied with A and B
+		applied, _, err := modifiedConfiguration(newcm(map[string]string{"A": "a", "B": "b"}))
+		require.NoError(t, err)
+		live := newcm(map[string]string{"A": "a", "B": "b", "C": "c"})
+		live.SetAnnotations(applied.GetAnnotations())
+
+		// B is removed from the manifest, C is set out-of-band
+		desired := newcm(map[string]string{"A": "a2"})
+		_, modified, err := modifiedConfiguration(desired)
+		require.NoError(t, err)
+
+		patch, pt, err := threeWayMergePatch(desired.GroupVersionKind(), live, modified)
+		require.NoError(t, err)
+		assert.Equal(t, types.StrategicMergePatchType, pt)
+
+		got := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(patch, &got))
+		data := got["data"].(map[string]interface{})
+		assert.Equal(t, "a2", data["A"])
+		assert.Contains(t, data, "B")
+		assert.Nil(t, data["B"])
+		assert.NotContains(t, data, "C")
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		cm := newcm(map[string]string{"A": "a"})
+		desired, modified, err := modifiedConfiguration(cm)
+		require.NoError(t, err)
+		patch, _, err := threeWayMergePatch(cm.GroupVersionKind(), desired, modified)
+		require.NoError(t, err)
+		assert.Equal(t, emptyPatch, string(patch))
+	})
+
+	t.Run("unregistered", func(t *testing.T) {
+		gvk := schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(gvk)
+		live.SetName("cert")
+		_ = unstructured.SetNestedField(live.Object, "a.example.com", "spec", "dnsName")
+		desired := live.DeepCopy()
+		_ = unstructured.SetNestedField(desired.Object, "b.example.com", "spec", "dnsName")
+		_, modified, err := modifiedConfiguration(desired)
+		require.NoError(t, err)
+
+		patch, pt, err := threeWayMergePatch(gvk, live, modified)
+		require.NoError(t, err)
+		assert.Equal(t, types.MergePatchType, pt)
+		assert.Contains(t, string(patch), "b.example.com")
+	})
+}
+
+func TestClientSideApplyLastApplied(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Service"), meta.RESTScopeNamespace)
+	client := dynamicfake.NewSimpleDynamicClient(scheme.Scheme)
+	var patches []string
+	client.PrependReactor("patch", "services", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patches = append(patches, string(action.(k8stesting.PatchAction).GetPatch()))
+		return false, nil, nil
+	})
+	cli := &Client{dynamic: client, mapper: staticRESTMapper{mapper}}
+
+	content := []byte(`apiVersion: v1
+kind: Service
+metadata:
+  name: kube-svc
+  namespace: default
+spec:
+  ports:
+    - port: 80
+`)
+	_, err := cli.ApplyBytes(context.TODO(), content, WithClientSideApply())
+	require.NoError(t, err)
+	live, err := cli.GetUnstructured(context.TODO(), corev1.SchemeGroupVersion.WithKind("Service"), "default", "kube-svc")
+	require.NoError(t, err)
+	// the manifest as it is, like kubectl
+	assert.JSONEq(t, `{"apiVersion":"v1","kind":"Service","metadata":{"name":"kube-svc","namespace":"default"},"spec":{"ports":[{"port":80}]}}`,
+		live.GetAnnotations()[corev1.LastAppliedConfigAnnotation])
+
+	// the server defaults targetPort, applying the same manifest again is a no-op
+	require.NoError(t, unstructured.SetNestedSlice(live.Object, []interface{}{
+		map[string]interface{}{"port": int64(80), "targetPort": int64(80), "protocol": "TCP"},
+	}, "spec", "ports"))
+	_, err = cli.UpdateUnstructured(context.TODO(), live)
+	require.NoError(t, err)
+	results, err := cli.ApplyBytes(context.TODO(), content, WithClientSideApply())
+	require.NoError(t, err)
+	assert.Equal(t, ActionUnchanged, results[0].Action)
+	assert.Empty(t, patches)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/version"
 )
 
@@ -15,35 +14,45 @@ func (c *Client) GetVersion() (*version.Info, error) {
 
 // GetNodes returns a NodeList.
 func (c *Client) GetNodes(ctx context.Context, label ...string) (*corev1.NodeList, error) {
-	return c.client.CoreV1().Nodes().List(ctx, listOptions(label))
+	return c.Nodes().List(ctx, labelListOptions(label))
+}
+
+// GetNodesWithOptions is like GetNodes, but with the given ListOption.
+func (c *Client) GetNodesWithOptions(ctx context.Context, opts ...ListOption) (*corev1.NodeList, error) {
+	return listWithOptions(ctx, c.Nodes().List, opts)
 }
 
 // GetNode returns a Node with the given name.
 func (c *Client) GetNode(ctx context.Context, name string) (*corev1.Node, error) {
-	return c.client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	return c.Nodes().Get(ctx, name)
 }
 
 // DeleteNode deletes a Node.
-func (c *Client) DeleteNode(ctx context.Context, name string) error {
-	return c.client.CoreV1().Nodes().Delete(ctx, name, metav1.DeleteOptions{})
+func (c *Client) DeleteNode(ctx context.Context, name string, opts ...DeleteOption) error {
+	return c.Nodes().Delete(ctx, name, opts...)
 }
 
 // GetNamespaces returns a NamespaceList.
 func (c *Client) GetNamespaces(ctx context.Context, label ...string) (*corev1.NamespaceList, error) {
-	return c.client.CoreV1().Namespaces().List(ctx, listOptions(label))
+	return c.Namespaces().List(ctx, labelListOptions(label))
+}
+
+// GetNamespacesWithOptions is like GetNamespaces, but with the given ListOption.
+func (c *Client) GetNamespacesWithOptions(ctx context.Context, opts ...ListOption) (*corev1.NamespaceList, error) {
+	return listWithOptions(ctx, c.Namespaces().List, opts)
 }
 
 // GetNamespace returns a Namespace with the given name.
 func (c *Client) GetNamespace(ctx context.Context, name string) (*corev1.Namespace, error) {
-	return c.client.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	return c.Namespaces().Get(ctx, name)
 }
 
 // CreateNamespace creates a Namespace.
 func (c *Client) CreateNamespace(ctx context.Context, namespace *corev1.Namespace) (*corev1.Namespace, error) {
-	return c.client.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{})
+	return c.Namespaces().Create(ctx, namespace)
 }
 
 // DeleteNamespace deletes a Node.
-func (c *Client) DeleteNamespace(ctx context.Context, name string) error {
-	return c.client.CoreV1().Namespaces().Delete(ctx, name, metav1.DeleteOptions{})
+func (c *Client) DeleteNamespace(ctx context.Context, name string, opts ...DeleteOption) error {
+	return c.Namespaces().Delete(ctx, name, opts...)
 }
@@ -3,10 +3,12 @@ package kube
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -53,8 +55,12 @@ func TestClientNamespace(t *testing.T) {
 	})
 
 	t.Run("delete:namespace", func(t *testing.T) {
-		err := mockcli.DeleteNamespace(context.TODO(), mocknsname)
+		err := mockcli.DeleteNamespace(context.TODO(), mocknsname,
+			WithPropagationPolicy(metav1.DeletePropagationForeground), WithWaitForDeletion(2*time.Minute))
 		require.NoError(t, err)
+
+		_, err = mockcli.GetNamespace(context.TODO(), mocknsname)
+		assert.True(t, apierrors.IsNotFound(err))
 	})
 }
 
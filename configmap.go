@@ -4,18 +4,22 @@ import (
 	"context"
 
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
 // GetConfigMaps returns a ConfigMapList.
 func (c *Client) GetConfigMaps(ctx context.Context, namespace string, label ...string) (*corev1.ConfigMapList, error) {
-	return c.client.CoreV1().ConfigMaps(namespace).List(ctx, listOptions(label))
+	return c.ConfigMaps(namespace).List(ctx, labelListOptions(label))
+}
+
+// GetConfigMapsWithOptions is like GetConfigMaps, but with the given ListOption.
+func (c *Client) GetConfigMapsWithOptions(ctx context.Context, namespace string, opts ...ListOption) (*corev1.ConfigMapList, error) {
+	return listWithOptions(ctx, c.ConfigMaps(namespace).List, opts)
 }
 
 // GetConfigMap returns a ConfigMap with the given name.
 func (c *Client) GetConfigMap(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error) {
-	return c.client.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	return c.ConfigMaps(namespace).Get(ctx, name)
 }
 
 // CreateConfigMap creates a new ConfigMap.
@@ -23,7 +27,7 @@ func (c *Client) CreateConfigMap(ctx context.Context, cm *corev1.ConfigMap) (*co
 	if len(cm.Namespace) == 0 {
 		return nil, ErrorMissingNamespace
 	}
-	return c.client.CoreV1().ConfigMaps(cm.Namespace).Create(ctx, cm, metav1.CreateOptions{})
+	return c.ConfigMaps(cm.Namespace).Create(ctx, cm)
 }
 
 // UpdateConfigMap updates a ConfigMap.
@@ -31,18 +35,12 @@ func (c *Client) UpdateConfigMap(ctx context.Context, cm *corev1.ConfigMap) (*co
 	if len(cm.Namespace) == 0 {
 		return nil, ErrorMissingNamespace
 	}
-	return c.client.CoreV1().ConfigMaps(cm.Namespace).Update(ctx, cm, metav1.UpdateOptions{})
+	return c.ConfigMaps(cm.Namespace).Update(ctx, cm)
 }
 
 // PatchConfigMap patch a ConfigMap.
 func (c *Client) PatchConfigMap(ctx context.Context, namespace, name string, data []byte) (*corev1.ConfigMap, error) {
-	return c.client.CoreV1().ConfigMaps(namespace).Patch(
-		ctx,
-		name,
-		types.StrategicMergePatchType,
-		data,
-		metav1.PatchOptions{},
-	)
+	return c.ConfigMaps(namespace).Patch(ctx, name, types.StrategicMergePatchType, data)
 }
 
 // ApplyConfigMap updates a ConfigMap, and creates a new ConfigMap if not exist.
@@ -60,6 +58,6 @@ func (c *Client) ApplyConfigMap(ctx context.Context, namespace, name string, dat
 }
 
 // DeleteConfigMap deletes a ConfigMap.
-func (c *Client) DeleteConfigMap(ctx context.Context, namespace, name string) error {
-	return c.client.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+func (c *Client) DeleteConfigMap(ctx context.Context, namespace, name string, opts ...DeleteOption) error {
+	return c.ConfigMaps(namespace).Delete(ctx, name, opts...)
 }
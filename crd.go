@@ -0,0 +1,77 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	crdGroup = "apiextensions.k8s.io"
+	crdKind  = "CustomResourceDefinition"
+
+	crdConditionEstablished   = "Established"
+	crdConditionNamesAccepted = "NamesAccepted"
+)
+
+var (
+	// CRDEstablishTimeout is the maximum time to wait for a newly applied
+	// CustomResourceDefinition to be established.
+	CRDEstablishTimeout = time.Minute
+
+	crdPollInterval = 500 * time.Millisecond
+)
+
+// isCRD reports whether the given object is a CustomResourceDefinition.
+func isCRD(obj runtime.Object) bool {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	return gvk.Group == crdGroup && gvk.Kind == crdKind
+}
+
+// waitForCRDEstablished waits for the CustomResourceDefinition with the given name
+// to reach the Established condition.
+func waitForCRDEstablished(ctx context.Context, ri dynamic.ResourceInterface, name string) error {
+	err := wait.PollUntilContextTimeout(ctx, crdPollInterval, CRDEstablishTimeout, true, func(ctx context.Context) (bool, error) {
+		obj, err := ri.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return crdEstablished(obj)
+	})
+	if err != nil {
+		return fmt.Errorf("wait for CustomResourceDefinition %s to be established: %w", name, err)
+	}
+	return nil
+}
+
+// crdEstablished reports whether the given CustomResourceDefinition is established.
+// An error is returned if its names are not accepted, as it will never be established.
+func crdEstablished(obj runtime.Object) (bool, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return false, err
+	}
+	conditions, _, _ := unstructured.NestedSlice(content, "status", "conditions")
+	established := false
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch cond["type"] {
+		case crdConditionEstablished:
+			established = cond["status"] == "True"
+		case crdConditionNamesAccepted:
+			if cond["status"] == "False" {
+				return false, fmt.Errorf("names not accepted: %v", cond["message"])
+			}
+		}
+	}
+	return established, nil
+}
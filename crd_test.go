@@ -0,0 +1,66 @@
+package kube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestCRDEstablished(t *testing.T) {
+	newcrd := func(conditions ...interface{}) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(schema.GroupVersionKind{Group: crdGroup, Version: "v1", Kind: crdKind})
+		_ = unstructured.SetNestedSlice(u.Object, conditions, "status", "conditions")
+		return u
+	}
+
+	assert.True(t, isCRD(newcrd()))
+
+	established, err := crdEstablished(newcrd())
+	assert.NoError(t, err)
+	assert.False(t, established)
+
+	established, err = crdEstablished(newcrd(
+		map[string]interface{}{"type": "NamesAccepted", "status": "True"},
+		map[string]interface{}{"type": "Established", "status": "True"},
+	))
+	assert.NoError(t, err)
+	assert.True(t, established)
+
+	_, err = crdEstablished(newcrd(
+		map[string]interface{}{"type": "NamesAccepted", "status": "False", "message": "widgets is already in use"},
+	))
+	assert.ErrorContains(t, err, "already in use")
+}
+
+func TestClientApplyCRD(t *testing.T) {
+	files := []string{"testdata/content-crd.yaml"}
+
+	t.Run("apply:crd", func(t *testing.T) {
+		err := mockcli.Apply(files...)
+		require.NoError(t, err)
+
+		mapper, err := mockcli.RESTMapper()
+		require.NoError(t, err)
+		gvr, err := mapper.ResourceFor(schema.GroupVersionResource{Resource: "wd"})
+		require.NoError(t, err)
+		assert.Equal(t, "widgets", gvr.Resource)
+
+		dyn, err := mockcli.DialDynamic()
+		require.NoError(t, err)
+		w, err := dyn.Resource(gvr).Namespace("default").Get(context.TODO(), "kube-widget1", metav1.GetOptions{})
+		require.NoError(t, err)
+		size, _, _ := unstructured.NestedInt64(w.Object, "spec", "size")
+		assert.Equal(t, int64(1), size)
+	})
+
+	t.Run("delete:crd", func(t *testing.T) {
+		err := mockcli.DeleteWithOptions(context.TODO(), files)
+		require.NoError(t, err)
+	})
+}
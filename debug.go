@@ -0,0 +1,151 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+var debugPollInterval = time.Second
+
+// DefaultDebugTimeout is the default timeout waiting for the debug container to be running.
+const DefaultDebugTimeout = 5 * time.Minute
+
+// DebugOption configures the ephemeral container added by Debug.
+type DebugOption func(*debugOptions)
+
+type debugOptions struct {
+	name       string
+	target     string
+	command    []string
+	pullPolicy corev1.PullPolicy
+	timeout    time.Duration
+}
+
+// WithDebugContainerName sets the name of the debug container, defaults to "debugger-<random>".
+func WithDebugContainerName(name string) DebugOption {
+	return func(o *debugOptions) {
+		o.name = name
+	}
+}
+
+// WithDebugTarget is like 'kubectl debug --target', the debug container shares the process
+// namespace of the given container, its file system is reachable through /proc/<pid>/root.
+func WithDebugTarget(container string) DebugOption {
+	return func(o *debugOptions) {
+		o.target = container
+	}
+}
+
+// WithDebugCommand overrides the entrypoint of the debug image. The command must keep running,
+// e.g. "sleep infinity", for the debug container to be usable by Exec, Upload and Download.
+func WithDebugCommand(command ...string) DebugOption {
+	return func(o *debugOptions) {
+		o.command = command
+	}
+}
+
+// WithDebugPullPolicy sets the image pull policy of the debug container.
+func WithDebugPullPolicy(policy corev1.PullPolicy) DebugOption {
+	return func(o *debugOptions) {
+		o.pullPolicy = policy
+	}
+}
+
+// WithDebugTimeout sets the timeout waiting for the debug container to be running, defaults to DefaultDebugTimeout.
+func WithDebugTimeout(timeout time.Duration) DebugOption {
+	return func(o *debugOptions) {
+		o.timeout = timeout
+	}
+}
+
+func newDebugOptions(opts ...DebugOption) *debugOptions {
+	o := &debugOptions{timeout: DefaultDebugTimeout}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.name == "" {
+		o.name = fmt.Sprintf("debugger-%s", utilrand.String(5))
+	}
+	return o
+}
+
+// Debug is like 'kubectl debug', it adds an ephemeral container with the given image to the pod,
+// e.g. a distroless pod without shell or tar, and waits for it to be running. The returned name
+// of the container can be passed to Exec, Upload and Download.
+// The stdin of the container is kept open, so that the entrypoint of images like busybox, a shell, keeps running.
+func (c *Client) Debug(ctx context.Context, pod, namespace, image string, opts ...DebugOption) (string, error) {
+	o := newDebugOptions(opts...)
+	client, err := c.Dial()
+	if err != nil {
+		return "", err
+	}
+	container := corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:                     o.name,
+			Image:                    image,
+			Command:                  o.command,
+			ImagePullPolicy:          o.pullPolicy,
+			Stdin:                    true,
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		},
+		TargetContainerName: o.target,
+	}
+	// the pod is updated as a whole, its status may change in between, e.g. by the kubelet
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		p, err := client.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		p.Spec.EphemeralContainers = append(p.Spec.EphemeralContainers, container)
+		_, err = client.CoreV1().Pods(namespace).UpdateEphemeralContainers(ctx, pod, p, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("add debug container %s: %w", o.name, err)
+	}
+	err = waitForContainerRunning(ctx, o.name, o.timeout, func(ctx context.Context) (*corev1.Pod, error) {
+		return client.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+	})
+	if err != nil {
+		return "", err
+	}
+	return o.name, nil
+}
+
+// waitForContainerRunning waits for the given ephemeral container of the pod to be running.
+// It fails early if the container is terminated.
+func waitForContainerRunning(ctx context.Context, container string, timeout time.Duration,
+	get func(ctx context.Context) (*corev1.Pod, error)) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	err := wait.PollUntilContextCancel(ctx, debugPollInterval, true, func(ctx context.Context) (bool, error) {
+		p, err := get(ctx)
+		if err != nil {
+			return false, err
+		}
+		for _, s := range p.Status.EphemeralContainerStatuses {
+			if s.Name != container {
+				continue
+			}
+			if t := s.State.Terminated; t != nil {
+				return false, fmt.Errorf("terminated with exit code %d: %s", t.ExitCode, t.Reason)
+			}
+			return s.State.Running != nil, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("wait for debug container %s to be running: %w", container, err)
+	}
+	return nil
+}
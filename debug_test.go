@@ -0,0 +1,116 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestClientDebug(t *testing.T) {
+	podName, containerName, podNamespace := getResourceNames(t)
+
+	debugger, err := mockcli.Debug(context.TODO(), podName, podNamespace, "busybox:1.36",
+		WithDebugTarget(containerName), WithDebugTimeout(2*time.Minute))
+	require.NoError(t, err)
+
+	stdout, _, err := mockcli.Exec(podName, debugger, podNamespace, "echo", "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", stdout)
+}
+
+func TestDebug(t *testing.T) {
+	interval := debugPollInterval
+	debugPollInterval = 10 * time.Millisecond
+	defer func() { debugPollInterval = interval }()
+
+	client := fake.NewClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}}},
+	})
+	// the kubelet starts the ephemeral container
+	client.PrependReactor("update", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "ephemeralcontainers" {
+			return false, nil, nil
+		}
+		pod := action.(k8stesting.UpdateAction).GetObject().(*corev1.Pod)
+		for _, ec := range pod.Spec.EphemeralContainers {
+			pod.Status.EphemeralContainerStatuses = append(pod.Status.EphemeralContainerStatuses, corev1.ContainerStatus{
+				Name:  ec.Name,
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			})
+		}
+		return false, nil, nil
+	})
+	// the first update conflicts with a status update of the kubelet
+	conflicts := 1
+	client.PrependReactor("update", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "ephemeralcontainers" || conflicts == 0 {
+			return false, nil, nil
+		}
+		conflicts--
+		return true, nil, apierrors.NewConflict(corev1.Resource("pods"), "nginx", errors.New("the object has been modified"))
+	})
+	cli := &Client{client: client}
+
+	name, err := cli.Debug(context.TODO(), "nginx", "default", "busybox",
+		WithDebugContainerName("debugger"), WithDebugTarget("nginx"), WithDebugCommand("sleep", "infinity"))
+	require.NoError(t, err)
+	assert.Equal(t, "debugger", name)
+	assert.Zero(t, conflicts)
+
+	pod, err := cli.GetPod(context.TODO(), "default", "nginx")
+	require.NoError(t, err)
+	require.Len(t, pod.Spec.EphemeralContainers, 1)
+	ec := pod.Spec.EphemeralContainers[0]
+	assert.Equal(t, "nginx", ec.TargetContainerName)
+	assert.Equal(t, "busybox", ec.Image)
+	assert.Equal(t, []string{"sleep", "infinity"}, ec.Command)
+	assert.True(t, ec.Stdin)
+
+	require.NoError(t, cli.validateExecResource(context.TODO(), "nginx", "debugger", "default"))
+	require.Error(t, cli.validateExecResource(context.TODO(), "nginx", "unknown", "default"))
+
+	_, err = cli.Debug(context.TODO(), "unknown", "default", "busybox")
+	require.Error(t, err)
+}
+
+func TestWaitForContainerRunning(t *testing.T) {
+	interval := debugPollInterval
+	debugPollInterval = 10 * time.Millisecond
+	defer func() { debugPollInterval = interval }()
+
+	status := func(state corev1.ContainerState) func(ctx context.Context) (*corev1.Pod, error) {
+		return func(ctx context.Context) (*corev1.Pod, error) {
+			return &corev1.Pod{Status: corev1.PodStatus{EphemeralContainerStatuses: []corev1.ContainerStatus{
+				{Name: "debugger", State: state},
+			}}}, nil
+		}
+	}
+
+	err := waitForContainerRunning(context.TODO(), "debugger", time.Second,
+		status(corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}))
+	require.NoError(t, err)
+
+	err = waitForContainerRunning(context.TODO(), "debugger", time.Second,
+		status(corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 127, Reason: "Error"}}))
+	assert.EqualError(t, err, "wait for debug container debugger to be running: terminated with exit code 127: Error")
+
+	err = waitForContainerRunning(context.TODO(), "debugger", 50*time.Millisecond,
+		status(corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	err = waitForContainerRunning(context.TODO(), "debugger", time.Second, func(ctx context.Context) (*corev1.Pod, error) {
+		return nil, errors.New("connection refused")
+	})
+	assert.EqualError(t, err, "wait for debug container debugger to be running: connection refused")
+}
@@ -0,0 +1,116 @@
+package kube
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+const (
+	// readerSource names objects decoded from an io.Reader in errors.
+	readerSource = "<reader>"
+
+	decoderBufferSize = 4096
+)
+
+// decodeObjects decodes the stream of YAML or JSON documents read from the given reader.
+// Empty and comment-only documents are skipped, and List kinds are flattened into their items.
+// The source names the reader in errors, along with the 1-based index of the document.
+// Kinds registered in the scheme are decoded to typed objects if typed is set, otherwise all
+// objects are decoded to unstructured.Unstructured holding only the fields of the document.
+func decodeObjects(r io.Reader, source string, typed bool) ([]runtime.Object, error) {
+	objs := make([]runtime.Object, 0)
+
+	decoder := yaml.NewYAMLOrJSONDecoder(r, decoderBufferSize)
+	for index := 1; ; index++ {
+		var raw runtime.RawExtension
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("%s: document %d: %w", source, index, err)
+		}
+		data := bytes.TrimSpace(raw.Raw)
+		if len(data) == 0 {
+			continue
+		}
+		subs, err := decodeObject(data, typed)
+		if err != nil {
+			return nil, fmt.Errorf("%s: document %d: %w", source, index, err)
+		}
+		objs = append(objs, subs...)
+	}
+	return objs, nil
+}
+
+// decodeObject decodes the given JSON document, a list is flattened into its items.
+// Kinds not registered in the scheme, e.g. custom resources, are decoded to unstructured.Unstructured.
+// Typed objects carry the zero values of fields the document does not set, e.g. the targetPort
+// of a Service port, so they are only decoded if typed is set, never for the payload of an apply.
+func decodeObject(data []byte, typed bool) ([]runtime.Object, error) {
+	var (
+		obj runtime.Object
+		err error
+	)
+	if typed {
+		obj, _, err = scheme.Codecs.UniversalDeserializer().Decode(data, nil, nil)
+	}
+	if !typed || runtime.IsNotRegisteredError(err) {
+		obj, _, err = unstructured.UnstructuredJSONScheme.Decode(data, nil, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if list, ok := obj.(*corev1.List); ok {
+		var objs []runtime.Object
+		for i := range list.Items {
+			subs, err := decodeObject(list.Items[i].Raw, typed)
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %w", i, err)
+			}
+			objs = append(objs, subs...)
+		}
+		return objs, nil
+	}
+	if !meta.IsListType(obj) {
+		return []runtime.Object{obj}, nil
+	}
+	// The items of a typed list, e.g. ConfigMapList, may omit their kind.
+	items, err := meta.ExtractList(obj)
+	if err != nil {
+		return nil, err
+	}
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	objs := make([]runtime.Object, 0, len(items))
+	for i, item := range items {
+		if item.GetObjectKind().GroupVersionKind().Empty() {
+			item.GetObjectKind().SetGroupVersionKind(gvk)
+		}
+		if !meta.IsListType(item) {
+			objs = append(objs, item)
+			continue
+		}
+		// a list item of a v1 List decoded to unstructured
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		subs, err := decodeObject(data, typed)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		objs = append(objs, subs...)
+	}
+	return objs, nil
+}
@@ -0,0 +1,211 @@
+package kube
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDecodeObjects(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		kinds   []string
+		err     string
+	}{
+		{
+			"separator:comment",
+			`apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: sa1
+--- # second document
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: sa2
+`,
+			[]string{"ServiceAccount", "ServiceAccount"},
+			"",
+		},
+		{
+			"separator:in-string",
+			`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm1
+data:
+  script: |
+    echo "---"
+    ---
+  inline: "a---b"
+`,
+			[]string{"ConfigMap"},
+			"",
+		},
+		{
+			"empty:comment-only",
+			`---
+# only a comment
+---
+
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm1
+---
+`,
+			[]string{"ConfigMap"},
+			"",
+		},
+		{
+			"json",
+			`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm1"}}
+{"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "s1"}}`,
+			[]string{"ConfigMap", "Secret"},
+			"",
+		},
+		{
+			"list",
+			`apiVersion: v1
+kind: List
+items:
+  - apiVersion: v1
+    kind: ConfigMap
+    metadata:
+      name: cm1
+  - apiVersion: apps/v1
+    kind: Deployment
+    metadata:
+      name: deploy1
+`,
+			[]string{"ConfigMap", "Deployment"},
+			"",
+		},
+		{
+			"list:typed",
+			`apiVersion: v1
+kind: ConfigMapList
+items:
+  - metadata:
+      name: cm1
+  - metadata:
+      name: cm2
+`,
+			[]string{"ConfigMap", "ConfigMap"},
+			"",
+		},
+		{
+			"unregistered",
+			`apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: cert1
+spec:
+  dnsNames:
+    - example.com
+---
+apiVersion: v1
+kind: List
+items:
+  - apiVersion: monitoring.coreos.com/v1
+    kind: ServiceMonitor
+    metadata:
+      name: sm1
+`,
+			[]string{"Certificate", "ServiceMonitor"},
+			"",
+		},
+		{
+			"unregistered:list",
+			`apiVersion: monitoring.coreos.com/v1
+kind: ServiceMonitorList
+items:
+  - apiVersion: monitoring.coreos.com/v1
+    kind: ServiceMonitor
+    metadata:
+      name: sm1
+  - apiVersion: monitoring.coreos.com/v1
+    kind: ServiceMonitor
+    metadata:
+      name: sm2
+`,
+			[]string{"ServiceMonitor", "ServiceMonitor"},
+			"",
+		},
+		{
+			"error:index",
+			`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm1
+---
+apiVersion: v1
+metadata:
+  name: cm2
+`,
+			nil,
+			"test.yaml: document 2:",
+		},
+	}
+
+	for _, v := range tests {
+		for _, typed := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s:typed=%t", v.name, typed), func(t *testing.T) {
+				objs, err := decodeObjects(strings.NewReader(v.content), "test.yaml", typed)
+				if v.err != "" {
+					require.ErrorContains(t, err, v.err)
+					return
+				}
+				require.NoError(t, err)
+				var kinds []string
+				for _, o := range objs {
+					kinds = append(kinds, o.GetObjectKind().GroupVersionKind().Kind)
+					if !typed {
+						assert.IsType(t, &unstructured.Unstructured{}, o)
+					}
+				}
+				assert.Equal(t, v.kinds, kinds)
+			})
+		}
+	}
+
+	t.Run("unregistered:content", func(t *testing.T) {
+		objs, err := decodeObjects(strings.NewReader(tests[6].content), "test.yaml", true)
+		require.NoError(t, err)
+		u, ok := objs[0].(*unstructured.Unstructured)
+		require.True(t, ok)
+		dnsNames, _, _ := unstructured.NestedStringSlice(u.Object, "spec", "dnsNames")
+		assert.Equal(t, []string{"example.com"}, dnsNames)
+	})
+
+	t.Run("separator:in-string:content", func(t *testing.T) {
+		objs, err := decodeObjects(strings.NewReader(tests[1].content), "test.yaml", true)
+		require.NoError(t, err)
+		cm, ok := objs[0].(*corev1.ConfigMap)
+		require.True(t, ok)
+		assert.Equal(t, "a---b", cm.Data["inline"])
+		assert.Contains(t, cm.Data["script"], "---")
+	})
+
+	t.Run("unstructured:zero-values", func(t *testing.T) {
+		content := `apiVersion: v1
+kind: Service
+metadata:
+  name: svc1
+spec:
+  ports:
+    - port: 80
+`
+		objs, err := decodeObjects(strings.NewReader(content), "test.yaml", false)
+		require.NoError(t, err)
+		ports, _, _ := unstructured.NestedSlice(objs[0].(*unstructured.Unstructured).Object, "spec", "ports")
+		assert.Equal(t, []interface{}{map[string]interface{}{"port": int64(80)}}, ports)
+	})
+}
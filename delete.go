@@ -0,0 +1,139 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+var deletePollInterval = 500 * time.Millisecond
+
+// DeleteOption configures the deletion of objects, e.g. Client.DeletePod.
+type DeleteOption func(*deleteOptions)
+
+type deleteOptions struct {
+	metav1.DeleteOptions
+	wait        bool
+	waitTimeout time.Duration
+}
+
+// WithPropagationPolicy sets whether and how garbage collection is performed for the dependents
+// of deleted objects, e.g. metav1.DeletePropagationForeground.
+func WithPropagationPolicy(policy metav1.DeletionPropagation) DeleteOption {
+	return func(o *deleteOptions) {
+		o.PropagationPolicy = &policy
+	}
+}
+
+// WithGracePeriod sets the duration in seconds before deleted objects are deleted,
+// zero means delete immediately.
+func WithGracePeriod(seconds int64) DeleteOption {
+	return func(o *deleteOptions) {
+		o.GracePeriodSeconds = &seconds
+	}
+}
+
+// WithPreconditions deletes objects only if they have the given UID and resourceVersion,
+// an empty value is not checked.
+func WithPreconditions(uid types.UID, resourceVersion string) DeleteOption {
+	return func(o *deleteOptions) {
+		o.Preconditions = &metav1.Preconditions{}
+		if uid != "" {
+			o.Preconditions.UID = &uid
+		}
+		if resourceVersion != "" {
+			o.Preconditions.ResourceVersion = &resourceVersion
+		}
+	}
+}
+
+// WithWaitForDeletion waits for deleted objects to be gone, until the given timeout expires.
+// An object recreated with the same name in the meantime, i.e. with another UID, is not waited for.
+// A zero timeout means no timeout other than the deadline of the context.
+func WithWaitForDeletion(timeout time.Duration) DeleteOption {
+	return func(o *deleteOptions) {
+		o.wait = true
+		o.waitTimeout = timeout
+	}
+}
+
+func newDeleteOptions(opts ...DeleteOption) *deleteOptions {
+	o := &deleteOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// deleter is a typed client of a resource, e.g. corev1.PodInterface.
+type deleter[T any] interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+}
+
+// deleteResource deletes the object with the given name with the given options.
+func deleteResource[T any](ctx context.Context, client deleter[T], name string, opts []DeleteOption) error {
+	o := newDeleteOptions(opts...)
+	var uid types.UID
+	if o.wait {
+		obj, err := client.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if uid, err = objectUID(obj); err != nil {
+			return err
+		}
+	}
+	if err := client.Delete(ctx, name, o.DeleteOptions); err != nil {
+		return err
+	}
+	if !o.wait {
+		return nil
+	}
+	return waitForDeletion(ctx, name, uid, o.waitTimeout, func(ctx context.Context) (any, error) {
+		return client.Get(ctx, name, metav1.GetOptions{})
+	})
+}
+
+// waitForDeletion waits for the object with the given name and UID to be gone, i.e. get returns
+// a NotFound error, or an object with another UID, which has been recreated with the same name.
+func waitForDeletion(ctx context.Context, name string, uid types.UID, timeout time.Duration, get func(ctx context.Context) (any, error)) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	err := wait.PollUntilContextCancel(ctx, deletePollInterval, true, func(ctx context.Context) (bool, error) {
+		obj, err := get(ctx)
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		current, err := objectUID(obj)
+		if err != nil {
+			return false, err
+		}
+		return current != uid, nil
+	})
+	if err != nil {
+		return fmt.Errorf("wait for %s to be deleted: %w", name, err)
+	}
+	return nil
+}
+
+// objectUID returns the UID of the given object.
+func objectUID(obj any) (types.UID, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return "", err
+	}
+	return accessor.GetUID(), nil
+}
@@ -0,0 +1,82 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestNewDeleteOptions(t *testing.T) {
+	o := newDeleteOptions()
+	assert.Equal(t, metav1.DeleteOptions{}, o.DeleteOptions)
+	assert.False(t, o.wait)
+
+	o = newDeleteOptions(
+		WithPropagationPolicy(metav1.DeletePropagationOrphan),
+		WithGracePeriod(0),
+		WithPreconditions("uid1", ""),
+		WithWaitForDeletion(0),
+	)
+	assert.Equal(t, metav1.DeletePropagationOrphan, *o.PropagationPolicy)
+	assert.Equal(t, int64(0), *o.GracePeriodSeconds)
+	assert.Equal(t, types.UID("uid1"), *o.Preconditions.UID)
+	assert.Nil(t, o.Preconditions.ResourceVersion)
+	assert.True(t, o.wait)
+}
+
+func TestDeleteResource(t *testing.T) {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm1", Namespace: "default", UID: "uid1"}}
+	client := fake.NewSimpleClientset(cm).CoreV1().ConfigMaps("default")
+
+	err := deleteResource(context.TODO(), client, "cm1", []DeleteOption{WithGracePeriod(0), WithWaitForDeletion(time.Second)})
+	require.NoError(t, err)
+	_, err = client.Get(context.TODO(), "cm1", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	err = deleteResource(context.TODO(), client, "cm1", nil)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestDeleteResourceWait(t *testing.T) {
+	interval := deletePollInterval
+	deletePollInterval = 10 * time.Millisecond
+	defer func() { deletePollInterval = interval }()
+
+	t.Run("recreated", func(t *testing.T) {
+		cs := fake.NewClientset(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm1", Namespace: "default", UID: "uid1"}})
+		// the config map is recreated with the same name as soon as it is deleted
+		cs.PrependReactor("delete", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm1", Namespace: "default", UID: "uid2"}}
+			return true, nil, cs.Tracker().Update(corev1.SchemeGroupVersion.WithResource("configmaps"), cm, "default")
+		})
+		client := cs.CoreV1().ConfigMaps("default")
+
+		err := deleteResource(context.TODO(), client, "cm1", []DeleteOption{WithWaitForDeletion(time.Second)})
+		require.NoError(t, err)
+		cm, err := client.Get(context.TODO(), "cm1", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, types.UID("uid2"), cm.UID)
+	})
+
+	t.Run("finalizer", func(t *testing.T) {
+		cs := fake.NewClientset(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm1", Namespace: "default", UID: "uid1"}})
+		// the deletion is blocked by a finalizer
+		cs.PrependReactor("delete", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, nil
+		})
+		client := cs.CoreV1().ConfigMaps("default")
+
+		err := deleteResource(context.TODO(), client, "cm1", []DeleteOption{WithWaitForDeletion(50 * time.Millisecond)})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
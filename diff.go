@@ -0,0 +1,142 @@
+package kube
+
+import (
+	"context"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+// ChangeType describes how an object would be changed by an Operation.
+type ChangeType string
+
+const (
+	ChangeCreate    ChangeType = "create"
+	ChangeUpdate    ChangeType = "update"
+	ChangeUnchanged ChangeType = "unchanged"
+	ChangeDelete    ChangeType = "delete"
+)
+
+// ObjectDiff represents the change of an object parsed from the given files.
+type ObjectDiff struct {
+	GroupVersionKind schema.GroupVersionKind
+	Namespace        string
+	Name             string
+	Change           ChangeType
+	// Diff is the unified diff of the live object and the desired object in YAML,
+	// empty if the object is unchanged.
+	Diff string
+}
+
+// Diff is like 'kubectl diff -f'. It returns the changes that the given Operation would make
+// with the given ApplyOption, without persisting anything. The desired object of OperationApply
+// is computed by the server with DryRun=All, managed fields and status are ignored.
+func (c *Client) Diff(ctx context.Context, op Operation, files []string, opts ...ApplyOption) ([]ObjectDiff, error) {
+	o := newApplyOptions(opts...)
+	o.dryRun = true
+	objs, err := loadObjects(files, o.loadOptions())
+	if err != nil {
+		return nil, err
+	}
+	if !o.fileOrder {
+		sortObjects(objs, op)
+	}
+	if err = c.resolveNamespace(o); err != nil {
+		return nil, err
+	}
+	mapper, err := c.RESTMapper()
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := make([]ObjectDiff, 0, len(objs))
+	for i := range objs {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		obj, err := manifestObject(objs[i])
+		if err != nil {
+			return nil, err
+		}
+		ri, err := c.resourceInterface(mapper, obj, o)
+		if err != nil {
+			return nil, err
+		}
+		live, err := getObject(ctx, ri, obj.GetName())
+		if err != nil {
+			return nil, err
+		}
+		var desired *unstructured.Unstructured
+		if op == OperationApply {
+			desired, err = applyObjectWithOptions(ctx, ri, obj, live, o)
+			if err != nil {
+				return nil, err
+			}
+		}
+		d := ObjectDiff{
+			GroupVersionKind: obj.GroupVersionKind(),
+			Namespace:        obj.GetNamespace(),
+			Name:             obj.GetName(),
+		}
+		d.Change, d.Diff, err = diffObjects(live, desired)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, d)
+	}
+	return diffs, nil
+}
+
+// diffObjects compares the live object with the desired object, a nil
+// live object is created, a nil desired object is deleted.
+func diffObjects(live, desired *unstructured.Unstructured) (ChangeType, string, error) {
+	if live == nil && desired == nil {
+		return ChangeUnchanged, "", nil
+	}
+	from, err := diffableYAML(live)
+	if err != nil {
+		return "", "", err
+	}
+	to, err := diffableYAML(desired)
+	if err != nil {
+		return "", "", err
+	}
+	change := ChangeUpdate
+	switch {
+	case live == nil:
+		change = ChangeCreate
+	case desired == nil:
+		change = ChangeDelete
+	case from == to:
+		return ChangeUnchanged, "", nil
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from),
+		B:        difflib.SplitLines(to),
+		FromFile: "live",
+		ToFile:   "desired",
+		Context:  3,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return change, diff, nil
+}
+
+// diffableYAML returns the YAML of the given object without managed fields and status.
+func diffableYAML(obj *unstructured.Unstructured) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+	content := runtime.DeepCopyJSON(obj.Object)
+	unstructured.RemoveNestedField(content, "metadata", "managedFields")
+	unstructured.RemoveNestedField(content, "status")
+	data, err := yaml.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,115 @@
+package kube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestDiffObjects(t *testing.T) {
+	newcm := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kube-cm",
+				Namespace: "default",
+				ManagedFields: []metav1.ManagedFieldsEntry{
+					{Manager: "kube", Operation: metav1.ManagedFieldsOperationApply},
+				},
+			},
+			Data: data,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		live    *corev1.ConfigMap
+		desired *corev1.ConfigMap
+		change  ChangeType
+		diff    []string
+	}{
+		{"create", nil, newcm(map[string]string{"A": "a"}), ChangeCreate, []string{"+  A: a"}},
+		{"update", newcm(map[string]string{"A": "a"}), newcm(map[string]string{"A": "b"}), ChangeUpdate, []string{"-  A: a", "+  A: b"}},
+		{"unchanged", newcm(map[string]string{"A": "a"}), newcm(map[string]string{"A": "a"}), ChangeUnchanged, nil},
+		{"delete", newcm(map[string]string{"A": "a"}), nil, ChangeDelete, []string{"-  A: a"}},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			change, diff, err := diffObjects(toUnstructured(t, v.live), toUnstructured(t, v.desired))
+			require.NoError(t, err)
+			assert.Equal(t, v.change, change)
+			assert.NotContains(t, diff, "managedFields")
+			for _, line := range v.diff {
+				assert.Contains(t, diff, line)
+			}
+			if v.diff == nil {
+				assert.Empty(t, diff)
+			}
+		})
+	}
+}
+
+func TestClientDiff(t *testing.T) {
+	files := []string{"testdata/content-apply.yaml"}
+
+	// clean
+	err := mockcli.Delete(files...)
+	require.NoError(t, err)
+
+	t.Run("diff:apply:create", func(t *testing.T) {
+		diffs, err := mockcli.Diff(context.TODO(), OperationApply, files)
+		require.NoError(t, err)
+		require.Len(t, diffs, 4)
+		for _, d := range diffs {
+			assert.Equal(t, ChangeCreate, d.Change)
+		}
+		// nothing is persisted
+		_, err = mockcli.GetConfigMap(context.TODO(), "default", "kube-cm1")
+		require.Error(t, err)
+	})
+
+	t.Run("diff:apply:update", func(t *testing.T) {
+		err = mockcli.Apply(files...)
+		require.NoError(t, err)
+		diffs, err := mockcli.Diff(context.TODO(), OperationApply, []string{"testdata/content-apply2.yaml"})
+		require.NoError(t, err)
+		require.Len(t, diffs, 2)
+		assert.Equal(t, ChangeUpdate, diffs[0].Change)
+		assert.Contains(t, diffs[0].Diff, "+  TESTDATA: kube-cm1-multi")
+	})
+
+	t.Run("diff:delete", func(t *testing.T) {
+		diffs, err := mockcli.Diff(context.TODO(), OperationDelete, files)
+		require.NoError(t, err)
+		for _, d := range diffs {
+			assert.Equal(t, ChangeDelete, d.Change)
+		}
+	})
+
+	t.Run("delete:dry-run", func(t *testing.T) {
+		err = mockcli.DeleteWithOptions(context.TODO(), files, WithDryRun())
+		require.NoError(t, err)
+		_, err = mockcli.GetConfigMap(context.TODO(), "default", "kube-cm1")
+		require.NoError(t, err)
+	})
+
+	// clean
+	err = mockcli.Delete(files...)
+	require.NoError(t, err)
+}
+
+func toUnstructured(t *testing.T, cm *corev1.ConfigMap) *unstructured.Unstructured {
+	if cm == nil {
+		return nil
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cm)
+	require.NoError(t, err)
+	return &unstructured.Unstructured{Object: content}
+}
@@ -0,0 +1,163 @@
+package kube
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// mapperResetInterval is the minimum interval between the rediscoveries of the resources
+// caused by kinds that are not found.
+var mapperResetInterval = 10 * time.Second
+
+// DynamicResource returns the dynamic.ResourceInterface of the given GroupVersionKind, resolved
+// with the RESTMapper. The namespace is ignored for cluster-scoped kinds, an empty namespace of
+// namespaced kinds means all namespaces.
+func (c *Client) DynamicResource(gvk schema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, error) {
+	ri, _, err := c.dynamicResource(gvk, namespace)
+	return ri, err
+}
+
+// GetUnstructured returns the object of the given GroupVersionKind with the given name.
+func (c *Client) GetUnstructured(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
+	ri, err := c.namespacedResource(gvk, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return ri.Get(ctx, name, metav1.GetOptions{})
+}
+
+// ListUnstructured returns the list of objects of the given GroupVersionKind.
+func (c *Client) ListUnstructured(ctx context.Context, gvk schema.GroupVersionKind, namespace string, label ...string) (*unstructured.UnstructuredList, error) {
+	ri, err := c.DynamicResource(gvk, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return listAll(ctx, ri.List, labelListOptions(label))
+}
+
+// ListUnstructuredWithOptions is like ListUnstructured, but with the given ListOption.
+func (c *Client) ListUnstructuredWithOptions(ctx context.Context, gvk schema.GroupVersionKind, namespace string, opts ...ListOption) (*unstructured.UnstructuredList, error) {
+	ri, err := c.DynamicResource(gvk, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return listWithOptions(ctx, ri.List, opts)
+}
+
+// CreateUnstructured creates a new object, the GroupVersionKind is the apiVersion and kind of the object.
+func (c *Client) CreateUnstructured(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	ri, err := c.namespacedResource(obj.GroupVersionKind(), obj.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	return ri.Create(ctx, obj, metav1.CreateOptions{})
+}
+
+// UpdateUnstructured updates the given object, the GroupVersionKind is the apiVersion and kind of the object.
+func (c *Client) UpdateUnstructured(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	ri, err := c.namespacedResource(obj.GroupVersionKind(), obj.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	return ri.Update(ctx, obj, metav1.UpdateOptions{})
+}
+
+// PatchUnstructured patches the object of the given GroupVersionKind with the given name,
+// e.g. with types.MergePatchType. Custom resources do not support types.StrategicMergePatchType.
+func (c *Client) PatchUnstructured(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string,
+	pt types.PatchType, data []byte, subresources ...string) (*unstructured.Unstructured, error) {
+	ri, err := c.namespacedResource(gvk, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return ri.Patch(ctx, name, pt, data, metav1.PatchOptions{}, subresources...)
+}
+
+// DeleteUnstructured deletes the object of the given GroupVersionKind with the given name.
+func (c *Client) DeleteUnstructured(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string, opts ...DeleteOption) error {
+	ri, err := c.namespacedResource(gvk, namespace)
+	if err != nil {
+		return err
+	}
+	o := newDeleteOptions(opts...)
+	var uid types.UID
+	if o.wait {
+		live, err := ri.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		uid = live.GetUID()
+	}
+	if err = ri.Delete(ctx, name, o.DeleteOptions); err != nil {
+		return err
+	}
+	if !o.wait {
+		return nil
+	}
+	return waitForDeletion(ctx, name, uid, o.waitTimeout, func(ctx context.Context) (any, error) {
+		return ri.Get(ctx, name, metav1.GetOptions{})
+	})
+}
+
+// namespacedResource is like DynamicResource, but the namespace is required for namespaced kinds.
+func (c *Client) namespacedResource(gvk schema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, error) {
+	ri, namespaced, err := c.dynamicResource(gvk, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if namespaced && namespace == "" {
+		return nil, ErrorMissingNamespace
+	}
+	return ri, nil
+}
+
+func (c *Client) dynamicResource(gvk schema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, bool, error) {
+	mapper, err := c.RESTMapper()
+	if err != nil {
+		return nil, false, err
+	}
+	mapping, err := c.restMapping(mapper, gvk)
+	if err != nil {
+		return nil, false, err
+	}
+	dyn, err := c.DialDynamic()
+	if err != nil {
+		return nil, false, err
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return dyn.Resource(mapping.Resource).Namespace(namespace), true, nil
+	}
+	return dyn.Resource(mapping.Resource), false, nil
+}
+
+// restMapping returns the REST mapping of the given GroupVersionKind. If the kind is not found,
+// the resources are rediscovered and the mapping is retried, at most once per mapperResetInterval,
+// so that polling an unknown kind, e.g. in WaitForReady, does not rediscover on every attempt.
+func (c *Client) restMapping(mapper meta.ResettableRESTMapper, gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) && c.allowMapperReset() {
+		mapper.Reset()
+		mapping, err = mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	return mapping, err
+}
+
+// allowMapperReset reports whether the REST mapper can be reset after a NoMatch error,
+// and records the reset if it can.
+func (c *Client) allowMapperReset() bool {
+	c.resetMu.Lock()
+	defer c.resetMu.Unlock()
+	now := time.Now()
+	if !c.mapperResetAt.IsZero() && now.Sub(c.mapperResetAt) < mapperResetInterval {
+		return false
+	}
+	c.mapperResetAt = now
+	return true
+}
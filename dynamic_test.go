@@ -0,0 +1,115 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+type staticRESTMapper struct {
+	*meta.DefaultRESTMapper
+}
+
+func (staticRESTMapper) Reset() {}
+
+func TestDynamicResource(t *testing.T) {
+	rollout := schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout"}
+	clusterWidget := schema.GroupVersionKind{Group: "kube.shipengqi.io", Version: "v1", Kind: "ClusterWidget"}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(rollout, meta.RESTScopeNamespace)
+	mapper.Add(clusterWidget, meta.RESTScopeRoot)
+
+	cli := &Client{
+		dynamic: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			rollout.GroupVersion().WithResource("rollouts"):             "RolloutList",
+			clusterWidget.GroupVersion().WithResource("clusterwidgets"): "ClusterWidgetList",
+		}),
+		mapper: staticRESTMapper{mapper},
+	}
+	ctx := context.TODO()
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(rollout)
+	obj.SetName("rollout1")
+	_, err := cli.CreateUnstructured(ctx, obj)
+	require.ErrorIs(t, err, ErrorMissingNamespace)
+
+	obj.SetNamespace("default")
+	_, err = cli.CreateUnstructured(ctx, obj)
+	require.NoError(t, err)
+
+	got, err := cli.GetUnstructured(ctx, rollout, "default", "rollout1")
+	require.NoError(t, err)
+	assert.Equal(t, "rollout1", got.GetName())
+
+	got, err = cli.PatchUnstructured(ctx, rollout, "default", "rollout1", types.MergePatchType, []byte(`{"spec":{"replicas":2}}`))
+	require.NoError(t, err)
+	replicas, _, _ := unstructured.NestedInt64(got.Object, "spec", "replicas")
+	assert.Equal(t, int64(2), replicas)
+
+	list, err := cli.ListUnstructured(ctx, rollout, "")
+	require.NoError(t, err)
+	assert.Len(t, list.Items, 1)
+
+	err = cli.DeleteUnstructured(ctx, rollout, "default", "rollout1", WithWaitForDeletion(0))
+	require.NoError(t, err)
+	_, err = cli.GetUnstructured(ctx, rollout, "default", "rollout1")
+	assert.True(t, apierrors.IsNotFound(err))
+
+	widget := &unstructured.Unstructured{}
+	widget.SetGroupVersionKind(clusterWidget)
+	widget.SetName("widget1")
+	_, err = cli.CreateUnstructured(ctx, widget)
+	require.NoError(t, err)
+	_, err = cli.GetUnstructured(ctx, clusterWidget, "ignored", "widget1")
+	require.NoError(t, err)
+
+	_, err = cli.GetUnstructured(ctx, schema.GroupVersionKind{Group: "notexists", Version: "v1", Kind: "NotExists"}, "", "x")
+	assert.True(t, meta.IsNoMatchError(err))
+}
+
+// resetCountingRESTMapper counts the resets of the mapper.
+type resetCountingRESTMapper struct {
+	*meta.DefaultRESTMapper
+	resets int
+}
+
+func (m *resetCountingRESTMapper) Reset() { m.resets++ }
+
+func TestRESTMappingReset(t *testing.T) {
+	interval := mapperResetInterval
+	defer func() { mapperResetInterval = interval }()
+
+	rollout := schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout"}
+	unknown := schema.GroupVersionKind{Group: "kube.shipengqi.io", Version: "v1", Kind: "Unknown"}
+	mapper := &resetCountingRESTMapper{DefaultRESTMapper: meta.NewDefaultRESTMapper(nil)}
+	mapper.Add(rollout, meta.RESTScopeNamespace)
+	cli := &Client{}
+
+	_, err := cli.restMapping(mapper, rollout)
+	require.NoError(t, err)
+	assert.Zero(t, mapper.resets)
+
+	// an unknown kind polled repeatedly rediscovers the resources once per interval
+	mapperResetInterval = time.Hour
+	for range 3 {
+		_, err = cli.restMapping(mapper, unknown)
+		assert.True(t, meta.IsNoMatchError(err))
+	}
+	assert.Equal(t, 1, mapper.resets)
+
+	mapperResetInterval = 0
+	_, err = cli.restMapping(mapper, unknown)
+	assert.True(t, meta.IsNoMatchError(err))
+	assert.Equal(t, 2, mapper.resets)
+}
@@ -3,12 +3,16 @@ package kube
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
+	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
 )
 
 type ExecRequestMethod string
@@ -18,6 +22,19 @@ const (
 	ExecGetRequest  ExecRequestMethod = "GET"
 )
 
+// ExecProtocol is the streaming protocol used to execute commands in containers.
+type ExecProtocol string
+
+const (
+	// ExecProtocolFallback prefers the WebSocket protocol, and falls back to SPDY if the
+	// upgrade to WebSocket fails, e.g. through proxies or older API servers. It is the default.
+	ExecProtocolFallback ExecProtocol = "fallback"
+	// ExecProtocolWebSocket uses the WebSocket (v5 channel) protocol only.
+	ExecProtocolWebSocket ExecProtocol = "websocket"
+	// ExecProtocolSPDY uses the legacy SPDY protocol only.
+	ExecProtocolSPDY ExecProtocol = "spdy"
+)
+
 // Exec is like kubectl exec.
 func (c *Client) Exec(pod, container, namespace string, command ...string) (string, string, error) {
 	return c.exec(context.TODO(), pod, container, namespace, command...)
@@ -29,39 +46,193 @@ func (c *Client) ExecWithContext(ctx context.Context, pod, container, namespace
 }
 
 func (c *Client) exec(ctx context.Context, pod, container, namespace string, command ...string) (string, string, error) {
-	err := c.validateExecResource(ctx, pod, container, namespace)
+	var (
+		stderr bytes.Buffer
+		stdout bytes.Buffer
+	)
+	err := c.ExecStream(ctx, pod, container, namespace, ExecOptions{
+		Command: command,
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	})
 	if err != nil {
 		return "", "", err
 	}
+	return strings.TrimSpace(stdout.String()), strings.TrimSpace(stderr.String()), err
+}
+
+// ExecOptions configures Client.ExecStream.
+type ExecOptions struct {
+	Command []string
+	// Stdin is the stdin of the command, nil means no stdin.
+	Stdin io.Reader
+	// Stdout is the stdout of the command, nil means discarding it.
+	Stdout io.Writer
+	// Stderr is the stderr of the command, nil means discarding it.
+	// It is not used if TTY is set, as stderr is merged into stdout by the terminal.
+	Stderr io.Writer
+	// TTY allocates a terminal for the command, like 'kubectl exec -t'.
+	TTY bool
+	// TerminalSizeQueue delivers the size of the terminal when it is resized, it is used only if TTY is set.
+	// See TerminalResizer.
+	TerminalSizeQueue remotecommand.TerminalSizeQueue
+}
+
+// ExecStream is like 'kubectl exec -it', the stdin, stdout and stderr of the command are
+// streamed from and to the given ExecOptions. It returns when the command exits, or ctx is done.
+func (c *Client) ExecStream(ctx context.Context, pod, container, namespace string, opts ExecOptions) error {
+	err := c.validateExecResource(ctx, pod, container, namespace)
+	if err != nil {
+		return err
+	}
 
 	req := c.RemoteExecRequest(ExecPostRequest, pod, namespace, &corev1.PodExecOptions{
-		TypeMeta:  metav1.TypeMeta{},
-		Stdout:    true,
-		Stderr:    true,
+		Stdin:     opts.Stdin != nil,
+		Stdout:    opts.Stdout != nil,
+		Stderr:    opts.Stderr != nil && !opts.TTY,
+		TTY:       opts.TTY,
 		Container: container,
-		Command:   command,
+		Command:   opts.Command,
 	})
+	exec, err := c.RemoteExecutor(req)
+	if err != nil {
+		return err
+	}
+	stream := remotecommand.StreamOptions{
+		Stdin:  opts.Stdin,
+		Stdout: opts.Stdout,
+		Tty:    opts.TTY,
+	}
+	if opts.TTY {
+		stream.TerminalSizeQueue = opts.TerminalSizeQueue
+	} else {
+		stream.Stderr = opts.Stderr
+	}
+	return newExitError(exec.StreamWithContext(ctx, stream))
+}
+
+// ExecResult is the result of a command executed in a container.
+type ExecResult struct {
+	// Stdout is the untrimmed stdout of the command.
+	Stdout string
+	// Stderr is the untrimmed stderr of the command.
+	Stderr string
+	// ExitCode is the exit code of the command, -1 if the command did not exit,
+	// e.g. the connection is dropped.
+	ExitCode int
+	// Duration is the time taken by the command, from the request to the exit.
+	Duration time.Duration
+}
+
+// ExecWithResult is like ExecWithContext, but returns the ExecResult of the command. An *ExitError is
+// returned along with the result if the command exits with a non-zero code, any other error means
+// that the command could not be executed, or did not exit.
+func (c *Client) ExecWithResult(ctx context.Context, pod, container, namespace string, command ...string) (*ExecResult, error) {
 	var (
 		stderr bytes.Buffer
 		stdout bytes.Buffer
 	)
-	restcfg, err := c.RestConfig()
-	if err != nil {
-		return "", "", err
+	start := time.Now()
+	err := c.ExecStream(ctx, pod, container, namespace, ExecOptions{
+		Command: command,
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	})
+	result := &ExecResult{
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+		Duration: time.Since(start),
 	}
-	exec, err := remotecommand.NewSPDYExecutor(restcfg, "POST", req.URL())
-	if err != nil {
-		return "", "", err
+	var exitErr *ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode
+	default:
+		result.ExitCode = -1
 	}
-	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
-		Stdin:  nil,
-		Stdout: &stdout,
-		Stderr: &stderr,
-	})
-	if err != nil {
-		return "", "", err
+	return result, err
+}
+
+// ExitError is returned when a command executed in a container exits with a non-zero code.
+type ExitError struct {
+	ExitCode int
+
+	err error
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("command terminated with exit code %d", e.ExitCode)
+}
+
+// Unwrap returns the underlying exec.CodeExitError.
+func (e *ExitError) Unwrap() error {
+	return e.err
+}
+
+// IsExitError reports whether any error in err's chain is an ExitError.
+func IsExitError(err error) bool {
+	var ee *ExitError
+	return errors.As(err, &ee)
+}
+
+// newExitError converts the exit error of the stream to an ExitError, any other error is returned as it is.
+func newExitError(err error) error {
+	var exitErr utilexec.ExitError
+	if !errors.As(err, &exitErr) || !exitErr.Exited() {
+		return err
+	}
+	return &ExitError{ExitCode: exitErr.ExitStatus(), err: err}
+}
+
+// TerminalResizer is a remotecommand.TerminalSizeQueue that delivers the sizes given to Resize,
+// e.g. on SIGWINCH. Only the latest size is kept if the sizes are not consumed in time.
+type TerminalResizer struct {
+	sizes chan remotecommand.TerminalSize
+	done  chan struct{}
+	once  sync.Once
+}
+
+// NewTerminalResizer returns a new TerminalResizer.
+func NewTerminalResizer() *TerminalResizer {
+	return &TerminalResizer{
+		sizes: make(chan remotecommand.TerminalSize, 1),
+		done:  make(chan struct{}),
 	}
-	return strings.TrimSpace(stdout.String()), strings.TrimSpace(stderr.String()), err
+}
+
+// Resize sets the size of the terminal.
+func (r *TerminalResizer) Resize(width, height uint16) {
+	size := remotecommand.TerminalSize{Width: width, Height: height}
+	for {
+		select {
+		case <-r.done:
+			return
+		case r.sizes <- size:
+			return
+		default:
+		}
+		// drop the pending size in favor of the latest one
+		select {
+		case <-r.sizes:
+		default:
+		}
+	}
+}
+
+// Next returns the next size of the terminal, nil if the TerminalResizer is closed.
+func (r *TerminalResizer) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-r.sizes:
+		return &size
+	case <-r.done:
+		return nil
+	}
+}
+
+// Close stops delivering sizes.
+func (r *TerminalResizer) Close() {
+	r.once.Do(func() { close(r.done) })
 }
 
 func (c *Client) validateExecResource(ctx context.Context, pod, container, namespace string) error {
@@ -77,6 +248,13 @@ func (c *Client) validateExecResource(ctx context.Context, pod, container, names
 				break
 			}
 		}
+		// ephemeral containers, e.g. added by Debug
+		for _, co := range p.Spec.EphemeralContainers {
+			if co.Name == container {
+				found = true
+				break
+			}
+		}
 		if !found {
 			return fmt.Errorf("%s not found", container)
 		}
@@ -1,12 +1,19 @@
 package kube
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"net/url"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
 )
 
 const (
@@ -24,6 +31,101 @@ func TestClientExec(t *testing.T) {
 	assert.Equal(t, "hello", stdout)
 }
 
+func TestClientExecStream(t *testing.T) {
+	podName, containerName, podNamespace := getResourceNames(t)
+
+	t.Run("stdin", func(t *testing.T) {
+		var stdout bytes.Buffer
+		err := mockcli.ExecStream(context.TODO(), podName, containerName, podNamespace, ExecOptions{
+			Command: []string{"cat"},
+			Stdin:   strings.NewReader("hello"),
+			Stdout:  &stdout,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "hello", stdout.String())
+	})
+
+	t.Run("tty", func(t *testing.T) {
+		var stdout bytes.Buffer
+		resizer := NewTerminalResizer()
+		defer resizer.Close()
+		resizer.Resize(100, 40)
+		err := mockcli.ExecStream(context.TODO(), podName, containerName, podNamespace, ExecOptions{
+			Command:           []string{"stty", "size"},
+			Stdout:            &stdout,
+			TTY:               true,
+			TerminalSizeQueue: resizer,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "40 100", strings.TrimSpace(stdout.String()))
+	})
+}
+
+func TestClientExecWithResult(t *testing.T) {
+	podName, containerName, podNamespace := getResourceNames(t)
+
+	t.Run("success", func(t *testing.T) {
+		result, err := mockcli.ExecWithResult(context.TODO(), podName, containerName, podNamespace, "echo", "hello")
+		require.NoError(t, err)
+		assert.Equal(t, "hello\n", result.Stdout)
+		assert.Equal(t, 0, result.ExitCode)
+		assert.Positive(t, result.Duration)
+	})
+
+	t.Run("exit-code", func(t *testing.T) {
+		result, err := mockcli.ExecWithResult(context.TODO(), podName, containerName, podNamespace,
+			"sh", "-c", "echo failed >&2; exit 2")
+		require.True(t, IsExitError(err))
+		assert.Equal(t, 2, result.ExitCode)
+		assert.Equal(t, "failed\n", result.Stderr)
+	})
+}
+
+func TestNewExitError(t *testing.T) {
+	codeErr := utilexec.CodeExitError{Err: errors.New("command terminated with non-zero exit code"), Code: 2}
+	err := newExitError(fmt.Errorf("stream: %w", codeErr))
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.ExitCode)
+	assert.ErrorIs(t, err, codeErr)
+	assert.EqualError(t, err, "command terminated with exit code 2")
+
+	connErr := errors.New("connection reset by peer")
+	assert.Equal(t, connErr, newExitError(connErr))
+	assert.False(t, IsExitError(connErr))
+	assert.NoError(t, newExitError(nil))
+}
+
+func TestNewRemoteExecutor(t *testing.T) {
+	cfg := &rest.Config{Host: "https://127.0.0.1:6443"}
+	u, err := url.Parse("https://127.0.0.1:6443/api/v1/namespaces/default/pods/nginx/exec")
+	require.NoError(t, err)
+
+	for _, protocol := range []ExecProtocol{"", ExecProtocolFallback, ExecProtocolWebSocket, ExecProtocolSPDY} {
+		t.Run(string(protocol), func(t *testing.T) {
+			executor, err := newRemoteExecutor(cfg, protocol, u)
+			require.NoError(t, err)
+			_, fallback := executor.(*remotecommand.FallbackExecutor)
+			assert.Equal(t, protocol == "" || protocol == ExecProtocolFallback, fallback)
+		})
+	}
+
+	_, err = newRemoteExecutor(cfg, "http2", u)
+	assert.EqualError(t, err, `unsupported exec protocol "http2"`)
+}
+
+func TestTerminalResizer(t *testing.T) {
+	r := NewTerminalResizer()
+	r.Resize(80, 24)
+	r.Resize(100, 40)
+	assert.Equal(t, &remotecommand.TerminalSize{Width: 100, Height: 40}, r.Next())
+
+	r.Close()
+	r.Close()
+	assert.Nil(t, r.Next())
+	r.Resize(80, 24)
+}
+
 func getResourceNames(t *testing.T) (podName, containerName, podNamespace string) {
 	podNamespace = _defaultTestNamespace
 	podName = _defaultTestPod
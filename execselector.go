@@ -0,0 +1,115 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DefaultExecConcurrency is the default number of pods in which ExecSelector executes the command concurrently.
+const DefaultExecConcurrency = 10
+
+// ExecSelectorOption configures ExecSelector.
+type ExecSelectorOption func(*execSelectorOptions)
+
+type execSelectorOptions struct {
+	concurrency int
+	timeout     time.Duration
+}
+
+// WithExecConcurrency sets the maximum number of pods in which the command is executed concurrently.
+func WithExecConcurrency(n int) ExecSelectorOption {
+	return func(o *execSelectorOptions) {
+		if n > 0 {
+			o.concurrency = n
+		}
+	}
+}
+
+// WithExecTimeout sets the timeout of the command in each pod.
+func WithExecTimeout(timeout time.Duration) ExecSelectorOption {
+	return func(o *execSelectorOptions) {
+		o.timeout = timeout
+	}
+}
+
+func newExecSelectorOptions(opts ...ExecSelectorOption) *execSelectorOptions {
+	o := &execSelectorOptions{concurrency: DefaultExecConcurrency}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// ExecSelector executes the command in the given container of every running pod matching the label selector,
+// e.g. "app=nginx". The results are keyed by pod name, a pod in which the command could not be executed
+// has no result, or a partial one. The errors of all pods are joined, each prefixed with the pod name.
+func (c *Client) ExecSelector(ctx context.Context, namespace, selector, container string, command []string,
+	opts ...ExecSelectorOption) (map[string]*ExecResult, error) {
+	pods, err := c.GetPodsWithOptions(ctx, namespace, WithLabelSelector(selector))
+	if err != nil {
+		return nil, err
+	}
+	return execPods(ctx, runningPods(pods), newExecSelectorOptions(opts...),
+		func(ctx context.Context, pod string) (*ExecResult, error) {
+			return c.ExecWithResult(ctx, pod, container, namespace, command...)
+		})
+}
+
+// runningPods returns the sorted names of the running pods, which are not being deleted.
+func runningPods(pods *corev1.PodList) []string {
+	var names []string
+	for _, p := range pods.Items {
+		if p.Status.Phase == corev1.PodRunning && p.DeletionTimestamp == nil {
+			names = append(names, p.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// execPods calls fn for each of the given pods with bounded concurrency.
+func execPods(ctx context.Context, pods []string, o *execSelectorOptions,
+	fn func(ctx context.Context, pod string) (*ExecResult, error)) (map[string]*ExecResult, error) {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		errs    = make([]error, len(pods))
+		results = make(map[string]*ExecResult, len(pods))
+		sem     = make(chan struct{}, o.concurrency)
+	)
+	for i, pod := range pods {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = fmt.Errorf("pod %s: %w", pod, ctx.Err())
+			continue
+		}
+		wg.Go(func() {
+			defer func() { <-sem }()
+
+			pctx := ctx
+			if o.timeout > 0 {
+				var cancel context.CancelFunc
+				pctx, cancel = context.WithTimeout(ctx, o.timeout)
+				defer cancel()
+			}
+			result, err := fn(pctx, pod)
+			if err != nil {
+				errs[i] = fmt.Errorf("pod %s: %w", pod, err)
+			}
+			if result != nil {
+				mu.Lock()
+				results[pod] = result
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+	return results, errors.Join(errs...)
+}
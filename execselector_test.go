@@ -0,0 +1,91 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestClientExecSelector(t *testing.T) {
+	podName, containerName, podNamespace := getResourceNames(t)
+	pod, err := mockcli.GetPod(context.TODO(), podNamespace, podName)
+	require.NoError(t, err)
+
+	results, err := mockcli.ExecSelector(context.TODO(), podNamespace, labels.SelectorFromSet(pod.Labels).String(),
+		containerName, []string{"echo", "hello"}, WithExecConcurrency(2), WithExecTimeout(time.Minute))
+	require.NoError(t, err)
+	require.Contains(t, results, podName)
+	assert.Equal(t, "hello\n", results[podName].Stdout)
+}
+
+func TestRunningPods(t *testing.T) {
+	now := metav1.Now()
+	pods := &corev1.PodList{Items: []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-2"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-1"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-3"}, Status: corev1.PodStatus{Phase: corev1.PodPending}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-4", DeletionTimestamp: &now}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+	}}
+	assert.Equal(t, []string{"web-1", "web-2"}, runningPods(pods))
+}
+
+func TestExecPods(t *testing.T) {
+	pods := []string{"web-1", "web-2", "web-3", "web-4", "web-5"}
+
+	t.Run("concurrency", func(t *testing.T) {
+		var running, peak atomic.Int32
+		results, err := execPods(context.TODO(), pods, newExecSelectorOptions(WithExecConcurrency(2)),
+			func(ctx context.Context, pod string) (*ExecResult, error) {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				return &ExecResult{Stdout: pod}, nil
+			})
+		require.NoError(t, err)
+		assert.LessOrEqual(t, peak.Load(), int32(2))
+		require.Len(t, results, len(pods))
+		for _, pod := range pods {
+			assert.Equal(t, pod, results[pod].Stdout)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		results, err := execPods(context.TODO(), pods[:3], newExecSelectorOptions(),
+			func(ctx context.Context, pod string) (*ExecResult, error) {
+				switch pod {
+				case "web-1":
+					return &ExecResult{ExitCode: 2}, &ExitError{ExitCode: 2}
+				case "web-2":
+					return nil, errors.New("connection refused")
+				}
+				return &ExecResult{}, nil
+			})
+		assert.EqualError(t, err, "pod web-1: command terminated with exit code 2\npod web-2: connection refused")
+		assert.True(t, IsExitError(err))
+		assert.Len(t, results, 2)
+		assert.Equal(t, 2, results["web-1"].ExitCode)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		_, err := execPods(context.TODO(), pods[:1], newExecSelectorOptions(WithExecTimeout(10*time.Millisecond)),
+			func(ctx context.Context, pod string) (*ExecResult, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -1,16 +1,17 @@
 package kube
 
 import (
-	"bytes"
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/cli-runtime/pkg/resource"
-	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/client-go/util/homedir"
@@ -25,9 +26,8 @@ const (
 )
 
 var (
-	defaultYamlDelimiter = []byte("---")
-	defaultConfigDir     = filepath.Join(homedir.HomeDir(), DefaultKubeHomeDir)
-	defaultHomeFile      = filepath.Join(defaultConfigDir, DefaultKubeConfigFileName)
+	defaultConfigDir = filepath.Join(homedir.HomeDir(), DefaultKubeHomeDir)
+	defaultHomeFile  = filepath.Join(defaultConfigDir, DefaultKubeConfigFileName)
 )
 
 // RetrievesDefaultKubeConfig returns a available kubeconfig file.
@@ -52,69 +52,26 @@ func LoadDefaultKubeConfig() (*clientcmdapi.Config, error) {
 }
 
 // GetObjects returns the list of objects parsed from the given files.
+// It is like LoadObjects without LoadOption.
 func GetObjects(files ...string) ([]runtime.Object, error) {
-	var objs []runtime.Object
-	for _, f := range files {
-		fBytes, err := os.ReadFile(f)
-		if err != nil {
-			return nil, err
-		}
-		subs, err := getObjects(fBytes)
-		if err != nil {
-			return nil, err
-		}
-		if len(subs) > 0 {
-			objs = append(objs, subs...)
-		}
-	}
-	return objs, nil
+	return LoadObjects(files)
 }
 
-func getObjects(content []byte) ([]runtime.Object, error) {
-	objs := make([]runtime.Object, 0)
-
-	delimited := bytes.Split(content, defaultYamlDelimiter)
-	for _, del := range delimited {
-		if len(del) == 0 {
-			continue
-		}
-		decode := scheme.Codecs.UniversalDeserializer().Decode
-		obj, _, err := decode(del, nil, nil)
-		if err != nil {
-			return nil, err
-		}
-		objs = append(objs, obj)
-		// switch o := obj.(type) {
-		// case *corev1.Pod:
-		// default:
-		// }
+func applyObject(ctx context.Context, ri dynamic.ResourceInterface, obj, live *unstructured.Unstructured, opts *applyOptions) (*unstructured.Unstructured, error) {
+	if live == nil {
+		return ri.Create(ctx, obj, metav1.CreateOptions{DryRun: opts.dryRunAll()})
 	}
-	return objs, nil
+	obj.SetResourceVersion(live.GetResourceVersion())
+	return ri.Update(ctx, obj, metav1.UpdateOptions{DryRun: opts.dryRunAll()})
 }
 
-func applyObject(helper *resource.Helper, namespace, name string, obj runtime.Object) error {
-	if _, err := helper.Get(namespace, name); err != nil {
-		_, err = helper.Create(namespace, false, obj)
-		if err != nil {
-			return err
-		}
-	} else {
-		_, err = helper.Replace(namespace, name, true, obj)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func deleteObject(helper *resource.Helper, namespace, name string) error {
-	if _, err := helper.Get(namespace, name); err == nil {
-		_, err = helper.Delete(namespace, name)
-		if err != nil {
-			return err
-		}
+// getObject returns the object with the given name, or nil if it is not found.
+func getObject(ctx context.Context, ri dynamic.ResourceInterface, name string) (*unstructured.Unstructured, error) {
+	obj, err := ri.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
 	}
-	return nil
+	return obj, err
 }
 
 func retrievesMetaFromObject(obj runtime.Object) (namespace, name string, err error) {
@@ -151,7 +108,7 @@ func isset(s *string) bool {
 	return s != nil && len(*s) != 0
 }
 
-func listOptions(label []string) metav1.ListOptions {
+func labelListOptions(label []string) metav1.ListOptions {
 	opts := metav1.ListOptions{}
 	if len(label) > 0 {
 		opts.LabelSelector = strings.Join(label, ",")
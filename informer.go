@@ -0,0 +1,271 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/cache"
+)
+
+// InformerOption configures the informers of Client.InformerFactory, Client.DynamicInformerFactory and Watch.
+type InformerOption func(*informerOptions)
+
+type informerOptions struct {
+	namespace     string
+	resync        time.Duration
+	labelSelector string
+	fieldSelector string
+}
+
+// WithInformerNamespace limits informers to the given namespace, informers are cluster-wide by default.
+func WithInformerNamespace(namespace string) InformerOption {
+	return func(o *informerOptions) {
+		o.namespace = namespace
+	}
+}
+
+// WithResync sets the resync period of informers, handlers receive an update event for
+// every object in the cache every period. Zero means no resync.
+func WithResync(period time.Duration) InformerOption {
+	return func(o *informerOptions) {
+		o.resync = period
+	}
+}
+
+// WithInformerLabelSelector limits informers to the objects matching the given label selector, e.g. "app=nginx".
+func WithInformerLabelSelector(selector string) InformerOption {
+	return func(o *informerOptions) {
+		o.labelSelector = selector
+	}
+}
+
+// WithInformerFieldSelector limits informers to the objects matching the given field selector,
+// e.g. "status.phase=Running".
+func WithInformerFieldSelector(selector string) InformerOption {
+	return func(o *informerOptions) {
+		o.fieldSelector = selector
+	}
+}
+
+func newInformerOptions(opts ...InformerOption) informerOptions {
+	o := informerOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func (o informerOptions) tweakListOptions(opts *metav1.ListOptions) {
+	if o.labelSelector != "" {
+		opts.LabelSelector = o.labelSelector
+	}
+	if o.fieldSelector != "" {
+		opts.FieldSelector = o.fieldSelector
+	}
+}
+
+// EventHandler handles the events of objects of type T, nil callbacks are ignored.
+type EventHandler[T runtime.Object] struct {
+	OnAdd    func(obj T)
+	OnUpdate func(oldObj, newObj T)
+	// OnDelete receives the last known state of the object,
+	// which may be stale if the deletion was missed while the watch was disconnected.
+	OnDelete func(obj T)
+}
+
+func (h EventHandler[T]) funcs() cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if o, ok := obj.(T); ok && h.OnAdd != nil {
+				h.OnAdd(o)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			o, ok1 := oldObj.(T)
+			n, ok2 := newObj.(T)
+			if ok1 && ok2 && h.OnUpdate != nil {
+				h.OnUpdate(o, n)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if o, ok := obj.(T); ok && h.OnDelete != nil {
+				h.OnDelete(o)
+			}
+		},
+	}
+}
+
+// InformerFactory returns the shared informer factory of built-in resources with the given options.
+// Factories are cached by options, so that informers are shared by callers with the same options.
+// The caller is responsible for starting the factory, e.g. factory.Start(ctx.Done()).
+func (c *Client) InformerFactory(opts ...InformerOption) (informers.SharedInformerFactory, error) {
+	o := newInformerOptions(opts...)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if factory, ok := c.informers[o]; ok {
+		return factory, nil
+	}
+	client, err := c.Dial()
+	if err != nil {
+		return nil, err
+	}
+	factory := informers.NewSharedInformerFactoryWithOptions(client, o.resync,
+		informers.WithNamespace(o.namespace), informers.WithTweakListOptions(o.tweakListOptions))
+	if c.informers == nil {
+		c.informers = map[informerOptions]informers.SharedInformerFactory{}
+	}
+	c.informers[o] = factory
+	return factory, nil
+}
+
+// DynamicInformerFactory is like InformerFactory, but for resources of any kind with unstructured objects,
+// e.g. custom resources.
+func (c *Client) DynamicInformerFactory(opts ...InformerOption) (dynamicinformer.DynamicSharedInformerFactory, error) {
+	o := newInformerOptions(opts...)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if factory, ok := c.dynamicInformers[o]; ok {
+		return factory, nil
+	}
+	dyn, err := c.DialDynamic()
+	if err != nil {
+		return nil, err
+	}
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dyn, o.resync, o.namespace, o.tweakListOptions)
+	if c.dynamicInformers == nil {
+		c.dynamicInformers = map[informerOptions]dynamicinformer.DynamicSharedInformerFactory{}
+	}
+	c.dynamicInformers[o] = factory
+	return factory, nil
+}
+
+// Watch watches the objects of the built-in type T, e.g. *corev1.Pod, and calls the given
+// handler for each event until ctx is done. The objects existing when the watch starts are
+// received as add events. Expired watches are relisted automatically, and objects are
+// resynced WithResync. An error is returned if the informer cannot be synced, e.g. the objects
+// cannot be listed because of missing permissions.
+//
+//	err := kube.Watch(ctx, cli, kube.EventHandler[*corev1.Pod]{
+//		OnUpdate: func(oldObj, newObj *corev1.Pod) { ... },
+//	}, kube.WithInformerNamespace("default"))
+func Watch[T runtime.Object](ctx context.Context, c *Client, handler EventHandler[T], opts ...InformerOption) error {
+	gvk, err := typeGroupVersionKind[T]()
+	if err != nil {
+		return err
+	}
+	mapper, err := c.RESTMapper()
+	if err != nil {
+		return err
+	}
+	mapping, err := c.restMapping(mapper, gvk)
+	if err != nil {
+		return err
+	}
+	client, err := c.Dial()
+	if err != nil {
+		return err
+	}
+	// A dedicated factory is used, so that the informer is stopped with ctx.
+	o := newInformerOptions(opts...)
+	factory := informers.NewSharedInformerFactoryWithOptions(client, o.resync,
+		informers.WithNamespace(o.namespace), informers.WithTweakListOptions(o.tweakListOptions))
+	informer, err := factory.ForResource(mapping.Resource)
+	if err != nil {
+		return err
+	}
+	return runInformer(ctx, informer.Informer(), handler.funcs(), factory.Start, factory.Shutdown)
+}
+
+// WatchUnstructured is like Watch, but for the objects of the given GroupVersionKind, e.g. custom resources.
+func (c *Client) WatchUnstructured(ctx context.Context, gvk schema.GroupVersionKind, handler EventHandler[*unstructured.Unstructured], opts ...InformerOption) error {
+	mapper, err := c.RESTMapper()
+	if err != nil {
+		return err
+	}
+	mapping, err := c.restMapping(mapper, gvk)
+	if err != nil {
+		return err
+	}
+	dyn, err := c.DialDynamic()
+	if err != nil {
+		return err
+	}
+	o := newInformerOptions(opts...)
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dyn, o.resync, o.namespace, o.tweakListOptions)
+	informer := factory.ForResource(mapping.Resource).Informer()
+	return runInformer(ctx, informer, handler.funcs(), factory.Start, factory.Shutdown)
+}
+
+// runInformer registers the handler, starts the informer and blocks until ctx is done.
+// The first error listing or watching the objects before the informer is synced is returned,
+// e.g. Forbidden, later errors are retried by the informer.
+func runInformer(ctx context.Context, informer cache.SharedIndexInformer, handler cache.ResourceEventHandler,
+	start func(stopCh <-chan struct{}), shutdown func()) error {
+	if _, err := informer.AddEventHandler(handler); err != nil {
+		return err
+	}
+	syncErr := make(chan error, 1)
+	err := informer.SetWatchErrorHandlerWithContext(func(ctx context.Context, r *cache.Reflector, err error) {
+		cache.DefaultWatchErrorHandler(ctx, r, err)
+		if informer.HasSynced() {
+			return
+		}
+		select {
+		case syncErr <- err:
+		default:
+		}
+	})
+	if err != nil {
+		return err
+	}
+	// The informer is stopped before shutdown, which waits for it.
+	ctx, cancel := context.WithCancel(ctx)
+	defer shutdown()
+	defer cancel()
+	start(ctx.Done())
+
+	synced := make(chan struct{})
+	go func() {
+		if cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+			close(synced)
+		}
+	}()
+	select {
+	case err = <-syncErr:
+		return fmt.Errorf("failed to sync informer cache: %w", err)
+	case <-synced:
+	case <-ctx.Done():
+		return nil
+	}
+	<-ctx.Done()
+	return nil
+}
+
+// typeGroupVersionKind returns the GroupVersionKind of the type T registered in the client-go scheme.
+func typeGroupVersionKind[T runtime.Object]() (schema.GroupVersionKind, error) {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Pointer {
+		return schema.GroupVersionKind{}, fmt.Errorf("%s is not a pointer type", typ)
+	}
+	obj, ok := reflect.New(typ.Elem()).Interface().(runtime.Object)
+	if !ok {
+		return schema.GroupVersionKind{}, fmt.Errorf("%s is not a runtime.Object", typ)
+	}
+	gvks, _, err := scheme.Scheme.ObjectKinds(obj)
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+	return gvks[0], nil
+}
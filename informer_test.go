@@ -0,0 +1,101 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestTypeGroupVersionKind(t *testing.T) {
+	gvk, err := typeGroupVersionKind[*corev1.Pod]()
+	require.NoError(t, err)
+	assert.Equal(t, corev1.SchemeGroupVersion.WithKind("Pod"), gvk)
+
+	_, err = typeGroupVersionKind[*unstructured.Unstructured]()
+	assert.Error(t, err)
+}
+
+func TestInformerFactory(t *testing.T) {
+	cli := &Client{client: fake.NewClientset()}
+
+	f1, err := cli.InformerFactory(WithInformerNamespace("default"))
+	require.NoError(t, err)
+	f2, err := cli.InformerFactory(WithInformerNamespace("default"))
+	require.NoError(t, err)
+	f3, err := cli.InformerFactory(WithInformerNamespace("default"), WithInformerLabelSelector("app=nginx"))
+	require.NoError(t, err)
+	assert.Same(t, f1, f2)
+	assert.NotSame(t, f1, f3)
+}
+
+func TestWatch(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Pod"), meta.RESTScopeNamespace)
+	client := fake.NewClientset()
+	cli := &Client{client: client, mapper: staticRESTMapper{mapper}}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	events := make(chan string, 10)
+	done := make(chan error)
+	go func() {
+		done <- Watch(ctx, cli, EventHandler[*corev1.Pod]{
+			OnAdd:    func(obj *corev1.Pod) { events <- "add:" + obj.Name },
+			OnUpdate: func(_, newObj *corev1.Pod) { events <- "update:" + newObj.Labels["app"] },
+			OnDelete: func(obj *corev1.Pod) { events <- "delete:" + obj.Name },
+		}, WithInformerNamespace("default"))
+	}()
+
+	next := func() string {
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(10 * time.Second):
+			return "timeout"
+		}
+	}
+	pods := client.CoreV1().Pods("default")
+	// The pod may be created before or after the initial list, it is received as an add event anyway.
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}}
+	_, err := pods.Create(ctx, pod, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "add:pod1", next())
+
+	pod.Labels = map[string]string{"app": "nginx"}
+	_, err = pods.Update(ctx, pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "update:nginx", next())
+
+	require.NoError(t, pods.Delete(ctx, "pod1", metav1.DeleteOptions{}))
+	assert.Equal(t, "delete:pod1", next())
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestWatchListError(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Pod"), meta.RESTScopeNamespace)
+	client := fake.NewClientset()
+	client.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(corev1.Resource("pods"), "", errors.New("missing permissions"))
+	})
+	cli := &Client{client: client, mapper: staticRESTMapper{mapper}}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancel()
+	err := Watch(ctx, cli, EventHandler[*corev1.Pod]{}, WithInformerNamespace("default"))
+	assert.True(t, apierrors.IsForbidden(err))
+	assert.NoError(t, ctx.Err())
+}
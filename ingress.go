@@ -4,17 +4,21 @@ import (
 	"context"
 
 	networkv1 "k8s.io/api/networking/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // GetIngress returns an Ingress with the given name.
 func (c *Client) GetIngress(ctx context.Context, namespace, name string) (*networkv1.Ingress, error) {
-	return c.client.NetworkingV1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
+	return c.Ingresses(namespace).Get(ctx, name)
 }
 
 // GetIngresses returns a IngressList.
 func (c *Client) GetIngresses(ctx context.Context, namespace string, label ...string) (*networkv1.IngressList, error) {
-	return c.client.NetworkingV1().Ingresses(namespace).List(ctx, listOptions(label))
+	return c.Ingresses(namespace).List(ctx, labelListOptions(label))
+}
+
+// GetIngressesWithOptions is like GetIngresses, but with the given ListOption.
+func (c *Client) GetIngressesWithOptions(ctx context.Context, namespace string, opts ...ListOption) (*networkv1.IngressList, error) {
+	return listWithOptions(ctx, c.Ingresses(namespace).List, opts)
 }
 
 // CreateIngress creates a new Ingress.
@@ -22,30 +26,35 @@ func (c *Client) CreateIngress(ctx context.Context, ing *networkv1.Ingress) (*ne
 	if len(ing.Namespace) == 0 {
 		return nil, ErrorMissingNamespace
 	}
-	return c.client.NetworkingV1().Ingresses(ing.Namespace).Create(ctx, ing, metav1.CreateOptions{})
+	return c.Ingresses(ing.Namespace).Create(ctx, ing)
 }
 
 // DeleteIngress deletes a Ingress.
-func (c *Client) DeleteIngress(ctx context.Context, namespace, name string) error {
-	return c.client.NetworkingV1().Ingresses(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+func (c *Client) DeleteIngress(ctx context.Context, namespace, name string, opts ...DeleteOption) error {
+	return c.Ingresses(namespace).Delete(ctx, name, opts...)
 }
 
 // GetIngressClass returns an IngressClass with the given name.
 func (c *Client) GetIngressClass(ctx context.Context, name string) (*networkv1.IngressClass, error) {
-	return c.client.NetworkingV1().IngressClasses().Get(ctx, name, metav1.GetOptions{})
+	return c.IngressClasses().Get(ctx, name)
 }
 
 // GetIngressClasses returns a IngressClassList.
 func (c *Client) GetIngressClasses(ctx context.Context, label ...string) (*networkv1.IngressClassList, error) {
-	return c.client.NetworkingV1().IngressClasses().List(ctx, listOptions(label))
+	return c.IngressClasses().List(ctx, labelListOptions(label))
+}
+
+// GetIngressClassesWithOptions is like GetIngressClasses, but with the given ListOption.
+func (c *Client) GetIngressClassesWithOptions(ctx context.Context, opts ...ListOption) (*networkv1.IngressClassList, error) {
+	return listWithOptions(ctx, c.IngressClasses().List, opts)
 }
 
 // CreateIngressClass creates a new IngressClass.
 func (c *Client) CreateIngressClass(ctx context.Context, ingc *networkv1.IngressClass) (*networkv1.IngressClass, error) {
-	return c.client.NetworkingV1().IngressClasses().Create(ctx, ingc, metav1.CreateOptions{})
+	return c.IngressClasses().Create(ctx, ingc)
 }
 
 // DeleteIngressClass deletes a IngressClass.
-func (c *Client) DeleteIngressClass(ctx context.Context, name string) error {
-	return c.client.NetworkingV1().IngressClasses().Delete(ctx, name, metav1.DeleteOptions{})
+func (c *Client) DeleteIngressClass(ctx context.Context, name string, opts ...DeleteOption) error {
+	return c.IngressClasses().Delete(ctx, name, opts...)
 }
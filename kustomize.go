@@ -0,0 +1,49 @@
+package kube
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// LoadKustomization returns the list of objects built from the given kustomization directory,
+// like 'kubectl kustomize <dir>'.
+func LoadKustomization(dir string) ([]runtime.Object, error) {
+	return loadKustomization(dir, true)
+}
+
+func loadKustomization(dir string, typed bool) ([]runtime.Object, error) {
+	k := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+	resources, err := k.Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return nil, fmt.Errorf("kustomize %s: %w", dir, err)
+	}
+	content, err := resources.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("kustomize %s: %w", dir, err)
+	}
+	return decodeObjects(bytes.NewReader(content), dir, typed)
+}
+
+// ApplyKustomize is like 'kubectl apply -k <dir>', the objects are built from the given
+// kustomization directory. LoadOption set WithLoadOptions are ignored.
+func (c *Client) ApplyKustomize(ctx context.Context, dir string, opts ...ApplyOption) (Results, error) {
+	return c.executeKustomize(ctx, OperationApply, dir, newApplyOptions(opts...))
+}
+
+// DeleteKustomize is like 'kubectl delete -k <dir>'.
+func (c *Client) DeleteKustomize(ctx context.Context, dir string, opts ...ApplyOption) (Results, error) {
+	return c.executeKustomize(ctx, OperationDelete, dir, newApplyOptions(opts...))
+}
+
+func (c *Client) executeKustomize(ctx context.Context, op Operation, dir string, opts *applyOptions) (Results, error) {
+	objs, err := loadKustomization(dir, false)
+	if err != nil {
+		return nil, err
+	}
+	return c.execute(ctx, op, objs, opts)
+}
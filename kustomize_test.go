@@ -0,0 +1,46 @@
+package kube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestLoadKustomization(t *testing.T) {
+	objs, err := LoadKustomization("testdata/kustomize/overlay")
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+	for _, obj := range objs {
+		cm, ok := obj.(*corev1.ConfigMap)
+		require.True(t, ok)
+		assert.Equal(t, "default", cm.Namespace)
+		assert.Contains(t, []string{"kube-kustomize-cm", "kube-kustomize-generated"}, cm.Name)
+	}
+
+	_, err = LoadKustomization("testdata/notexists")
+	assert.ErrorContains(t, err, "kustomize testdata/notexists")
+}
+
+func TestClientApplyKustomize(t *testing.T) {
+	dir := "testdata/kustomize/overlay"
+
+	t.Run("apply", func(t *testing.T) {
+		results, err := mockcli.ApplyKustomize(context.TODO(), dir)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		cm, err := mockcli.GetConfigMap(context.TODO(), "default", "kube-kustomize-generated")
+		require.NoError(t, err)
+		assert.Equal(t, "kube-kustomize-overlay", cm.Data["TESTDATA"])
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		results, err := mockcli.DeleteKustomize(context.TODO(), dir)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, ActionDeleted, results[0].Action)
+	})
+}
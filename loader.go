@@ -0,0 +1,158 @@
+package kube
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// manifestExtensions are the extensions of files loaded from a directory, like 'kubectl apply -f <dir>'.
+var manifestExtensions = []string{".json", ".yaml", ".yml"}
+
+// LoadOption configures how objects are loaded from files.
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	recursive bool
+	template  bool
+	values    map[string]interface{}
+	envsubst  bool
+	// unstructured decodes all objects to unstructured.Unstructured, for the payload of an apply.
+	unstructured bool
+}
+
+// WithRecursive is like 'kubectl apply -R', directories are loaded recursively.
+func WithRecursive() LoadOption {
+	return func(o *loadOptions) {
+		o.recursive = true
+	}
+}
+
+// LoadObjects returns the list of objects parsed from the given paths. A path can be a file,
+// a directory, or a glob pattern. Files in a directory are loaded if their extension is
+// one of .json, .yaml and .yml, sub directories are loaded only WithRecursive.
+func LoadObjects(paths []string, opts ...LoadOption) ([]runtime.Object, error) {
+	return loadObjects(paths, newLoadOptions(opts...))
+}
+
+func loadObjects(paths []string, o *loadOptions) ([]runtime.Object, error) {
+	files, err := expandPaths(paths, o.recursive)
+	if err != nil {
+		return nil, err
+	}
+	var objs []runtime.Object
+	for _, f := range files {
+		subs, err := readObjects(f, o)
+		if err != nil {
+			return nil, err
+		}
+		if len(subs) > 0 {
+			objs = append(objs, subs...)
+		}
+	}
+	return objs, nil
+}
+
+func newLoadOptions(opts ...LoadOption) *loadOptions {
+	o := &loadOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// rendered reports whether the content is rendered before decoding.
+func (o *loadOptions) rendered() bool {
+	return o.template || o.envsubst
+}
+
+func readObjects(file string, o *loadOptions) ([]runtime.Object, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	return readerObjects(f, file, o)
+}
+
+// readerObjects decodes the objects read from the given reader, the content is read
+// entirely and rendered first if required by the given options.
+func readerObjects(r io.Reader, source string, o *loadOptions) ([]runtime.Object, error) {
+	if !o.rendered() {
+		return decodeObjects(r, source, !o.unstructured)
+	}
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if content, err = render(source, content, o); err != nil {
+		return nil, err
+	}
+	return decodeObjects(bytes.NewReader(content), source, !o.unstructured)
+}
+
+// expandPaths expands the given paths to the list of files to load.
+func expandPaths(paths []string, recursive bool) ([]string, error) {
+	var files []string
+	for _, p := range paths {
+		matches := []string{p}
+		if isGlob(p) {
+			var err error
+			if matches, err = filepath.Glob(p); err != nil {
+				return nil, err
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no files match pattern %q", p)
+			}
+		}
+		for _, m := range matches {
+			info, err := os.Stat(m)
+			if err != nil {
+				return nil, err
+			}
+			if !info.IsDir() {
+				files = append(files, m)
+				continue
+			}
+			subs, err := walkDir(m, recursive)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, subs...)
+		}
+	}
+	return files, nil
+}
+
+// walkDir returns the manifest files in the given directory in lexical order.
+func walkDir(dir string, recursive bool) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if slices.Contains(manifestExtensions, filepath.Ext(path)) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+func isGlob(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
@@ -0,0 +1,37 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadObjects(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+		opts  []LoadOption
+		count int
+		err   string
+	}{
+		{"file", []string{"testdata/content.yaml"}, nil, 1, ""},
+		{"dir", []string{"testdata/manifests"}, nil, 2, ""},
+		{"dir:recursive", []string{"testdata/manifests"}, []LoadOption{WithRecursive()}, 3, ""},
+		{"glob", []string{"testdata/content-apply*.yaml"}, nil, 6, ""},
+		{"glob:nomatch", []string{"testdata/nomatch-*.yaml"}, nil, 0, "no files match pattern"},
+		{"notexists", []string{"testdata/notexists.yaml"}, nil, 0, "no such file or directory"},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			objs, err := LoadObjects(v.paths, v.opts...)
+			if v.err != "" {
+				require.ErrorContains(t, err, v.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, objs, v.count)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package kube
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// inClusterNamespaceFile is the file that holds the namespace of the pod service account.
+const inClusterNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+type namespaceMode int
+
+const (
+	namespaceUnset namespaceMode = iota
+	namespaceDefault
+	namespaceOverride
+)
+
+// resolveNamespace sets the namespace of the given options to the current namespace,
+// if a default or override namespace is required but not given.
+func (c *Client) resolveNamespace(opts *applyOptions) error {
+	if opts.namespaceMode == namespaceUnset || opts.namespace != "" {
+		return nil
+	}
+	ns, err := c.currentNamespace()
+	if err != nil {
+		return err
+	}
+	opts.namespace = ns
+	return nil
+}
+
+// currentNamespace returns the current namespace of the kubeconfig, or the namespace
+// of the pod service account if the Client is in cluster.
+func (c *Client) currentNamespace() (string, error) {
+	if !c.inCluster {
+		return c.cfg.CurrentNamespace()
+	}
+	data, err := os.ReadFile(inClusterNamespaceFile)
+	if ns := strings.TrimSpace(string(data)); err == nil && ns != "" {
+		return ns, nil
+	}
+	return metav1.NamespaceDefault, nil
+}
+
+// setNamespace sets the namespace of the given object according to the given options.
+// The namespace of objects of cluster-scoped kinds is cleared, or rejected WithStrictNamespace.
+func setNamespace(obj *unstructured.Unstructured, namespaced bool, opts *applyOptions) error {
+	if !namespaced {
+		if obj.GetNamespace() != "" {
+			if opts.strictNamespace {
+				return fmt.Errorf("%s %s is cluster-scoped, namespace %q is not allowed", obj.GetKind(), obj.GetName(), obj.GetNamespace())
+			}
+			obj.SetNamespace("")
+		}
+		return nil
+	}
+	switch opts.namespaceMode {
+	case namespaceOverride:
+		obj.SetNamespace(opts.namespace)
+	case namespaceDefault:
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(opts.namespace)
+		}
+	}
+	if obj.GetNamespace() == "" {
+		return ErrorMissingNamespace
+	}
+	return nil
+}
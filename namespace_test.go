@@ -0,0 +1,80 @@
+package kube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSetNamespace(t *testing.T) {
+	newobj := func(namespace string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetKind("ConfigMap")
+		u.SetName("cm")
+		u.SetNamespace(namespace)
+		return u
+	}
+
+	tests := []struct {
+		name       string
+		namespace  string
+		namespaced bool
+		opts       []ApplyOption
+		expected   string
+		err        string
+	}{
+		{"namespaced:unset", "ns1", true, nil, "ns1", ""},
+		{"namespaced:missing", "", true, nil, "", "missing namespace"},
+		{"namespaced:default", "", true, []ApplyOption{WithDefaultNamespace("ns2")}, "ns2", ""},
+		{"namespaced:default:set", "ns1", true, []ApplyOption{WithDefaultNamespace("ns2")}, "ns1", ""},
+		{"namespaced:override", "ns1", true, []ApplyOption{WithNamespaceOverride("ns2")}, "ns2", ""},
+		{"cluster-scoped", "ns1", false, []ApplyOption{WithNamespaceOverride("ns2")}, "", ""},
+		{"cluster-scoped:strict", "ns1", false, []ApplyOption{WithStrictNamespace()}, "", "cluster-scoped"},
+		{"cluster-scoped:strict:unset", "", false, []ApplyOption{WithStrictNamespace()}, "", ""},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			obj := newobj(v.namespace)
+			err := setNamespace(obj, v.namespaced, newApplyOptions(v.opts...))
+			if v.err != "" {
+				require.ErrorContains(t, err, v.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, v.expected, obj.GetNamespace())
+		})
+	}
+}
+
+func TestClientApplyDefaultNamespace(t *testing.T) {
+	content := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: kube-cm-nonamespace
+data:
+  TESTDATA: "kube-cm-nonamespace"
+`)
+
+	t.Run("apply:missing", func(t *testing.T) {
+		_, err := mockcli.ApplyBytes(context.TODO(), content)
+		require.ErrorIs(t, err, ErrorMissingNamespace)
+	})
+
+	t.Run("apply:default", func(t *testing.T) {
+		results, err := mockcli.ApplyBytes(context.TODO(), content, WithDefaultNamespace("default"))
+		require.NoError(t, err)
+		assert.Equal(t, "default", results[0].Namespace)
+		_, err = mockcli.GetConfigMap(context.TODO(), "default", "kube-cm-nonamespace")
+		require.NoError(t, err)
+	})
+
+	t.Run("delete:override", func(t *testing.T) {
+		results, err := mockcli.DeleteBytes(context.TODO(), content, WithNamespaceOverride("default"))
+		require.NoError(t, err)
+		assert.Equal(t, ActionDeleted, results[0].Action)
+	})
+}
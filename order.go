@@ -0,0 +1,74 @@
+package kube
+
+import (
+	"slices"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// InstallOrder is the order in which objects are applied, it is like the install order of Helm,
+// except that CustomResourceDefinition is applied right after Namespace.
+// Objects of kinds not in the list are applied last, objects are deleted in the reverse order.
+// references:
+// - https://github.com/helm/helm/blob/main/pkg/release/util/kind_sorter.go
+var InstallOrder = []string{
+	"PriorityClass",
+	"Namespace",
+	"CustomResourceDefinition",
+	"NetworkPolicy",
+	"ResourceQuota",
+	"LimitRange",
+	"PodSecurityPolicy",
+	"PodDisruptionBudget",
+	"ServiceAccount",
+	"Secret",
+	"SecretList",
+	"ConfigMap",
+	"StorageClass",
+	"PersistentVolume",
+	"PersistentVolumeClaim",
+	"ClusterRole",
+	"ClusterRoleList",
+	"ClusterRoleBinding",
+	"ClusterRoleBindingList",
+	"Role",
+	"RoleList",
+	"RoleBinding",
+	"RoleBindingList",
+	"Service",
+	"DaemonSet",
+	"Pod",
+	"ReplicationController",
+	"ReplicaSet",
+	"Deployment",
+	"HorizontalPodAutoscaler",
+	"StatefulSet",
+	"Job",
+	"CronJob",
+	"IngressClass",
+	"Ingress",
+	"APIService",
+	"MutatingWebhookConfiguration",
+	"ValidatingWebhookConfiguration",
+}
+
+// sortObjects sorts the given objects by InstallOrder for OperationApply,
+// and by the reverse of InstallOrder for OperationDelete. The order of
+// objects of the same kind is preserved.
+func sortObjects(objs []runtime.Object, op Operation) {
+	slices.SortStableFunc(objs, func(a, b runtime.Object) int {
+		ia, ib := installIndex(a), installIndex(b)
+		if op == OperationDelete {
+			return ib - ia
+		}
+		return ia - ib
+	})
+}
+
+func installIndex(obj runtime.Object) int {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if i := slices.Index(InstallOrder, kind); i >= 0 {
+		return i
+	}
+	return len(InstallOrder)
+}
@@ -0,0 +1,48 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSortObjects(t *testing.T) {
+	newobj := func(kind, name string) runtime.Object {
+		u := &unstructured.Unstructured{}
+		u.SetKind(kind)
+		u.SetName(name)
+		return u
+	}
+	names := func(objs []runtime.Object) []string {
+		var ns []string
+		for _, o := range objs {
+			ns = append(ns, o.(*unstructured.Unstructured).GetName())
+		}
+		return ns
+	}
+	objects := func() []runtime.Object {
+		return []runtime.Object{
+			newobj("Certificate", "cert"),
+			newobj("Deployment", "deploy"),
+			newobj("ConfigMap", "cm1"),
+			newobj("Namespace", "ns"),
+			newobj("CustomResourceDefinition", "crd"),
+			newobj("ConfigMap", "cm2"),
+			newobj("Ingress", "ing"),
+		}
+	}
+
+	t.Run("apply", func(t *testing.T) {
+		objs := objects()
+		sortObjects(objs, OperationApply)
+		assert.Equal(t, []string{"ns", "crd", "cm1", "cm2", "deploy", "ing", "cert"}, names(objs))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		objs := objects()
+		sortObjects(objs, OperationDelete)
+		assert.Equal(t, []string{"cert", "ing", "deploy", "cm1", "cm2", "crd", "ns"}, names(objs))
+	})
+}
@@ -0,0 +1,132 @@
+package kube
+
+import (
+	"context"
+	"iter"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DefaultPageSize is the number of objects requested per page by paginated lists.
+var DefaultPageSize int64 = 500
+
+// listPages lists the pages of objects with the given list function, and calls fn for each page
+// until there is no more page, or fn returns false. If the continue token of a page expires,
+// the objects are relisted in full without pagination, the full list is the last page.
+func listPages[L runtime.Object](ctx context.Context, list func(context.Context, metav1.ListOptions) (L, error),
+	opts metav1.ListOptions, fn func(page L, relisted bool) (bool, error)) error {
+	if opts.Limit == 0 {
+		opts.Limit = DefaultPageSize
+	}
+	for {
+		page, err := list(ctx, opts)
+		if apierrors.IsResourceExpired(err) && opts.Continue != "" {
+			opts.Limit, opts.Continue = 0, ""
+			if page, err = list(ctx, opts); err != nil {
+				return err
+			}
+			_, err = fn(page, true)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		more, err := fn(page, false)
+		if err != nil || !more {
+			return err
+		}
+		lm, err := meta.ListAccessor(page)
+		if err != nil {
+			return err
+		}
+		if lm.GetContinue() == "" {
+			return nil
+		}
+		opts.Continue = lm.GetContinue()
+	}
+}
+
+// listAll returns all objects with the given list function, paginated by DefaultPageSize
+// unless a limit is given. The pages are merged into the first one.
+func listAll[L runtime.Object](ctx context.Context, list func(context.Context, metav1.ListOptions) (L, error), opts metav1.ListOptions) (L, error) {
+	if opts.Limit > 0 || opts.Continue != "" {
+		return list(ctx, opts)
+	}
+	var (
+		result L
+		first  = true
+		items  []runtime.Object
+	)
+	err := listPages(ctx, list, opts, func(page L, relisted bool) (bool, error) {
+		if relisted {
+			result, items, first = page, nil, false
+			return false, nil
+		}
+		pageItems, err := meta.ExtractList(page)
+		if err != nil {
+			return false, err
+		}
+		if first {
+			result, first = page, false
+		}
+		items = append(items, pageItems...)
+		return true, nil
+	})
+	if err != nil {
+		return result, err
+	}
+	if len(items) == 0 {
+		return result, nil
+	}
+	if err = meta.SetList(result, items); err != nil {
+		return result, err
+	}
+	lm, err := meta.ListAccessor(result)
+	if err != nil {
+		return result, err
+	}
+	lm.SetContinue("")
+	return result, nil
+}
+
+// listItems returns an iterator over the objects listed with the given list function, page by page.
+// Objects already yielded are skipped if the objects are relisted in full after a continue token expires.
+func listItems[T, L runtime.Object](ctx context.Context, list func(context.Context, metav1.ListOptions) (L, error), opts metav1.ListOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		seen := map[string]bool{}
+		stopped := false
+		err := listPages(ctx, list, opts, func(page L, relisted bool) (bool, error) {
+			items, err := meta.ExtractList(page)
+			if err != nil {
+				return false, err
+			}
+			for _, item := range items {
+				obj, ok := item.(T)
+				if !ok {
+					continue
+				}
+				accessor, err := meta.Accessor(obj)
+				if err != nil {
+					return false, err
+				}
+				key := objectKey(accessor.GetNamespace(), accessor.GetName())
+				if relisted && seen[key] {
+					continue
+				}
+				seen[key] = true
+				if !yield(obj, nil) {
+					stopped = true
+					return false, nil
+				}
+			}
+			return true, nil
+		})
+		if err != nil && !stopped {
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// fakePodLister lists the given number of pods page by page, the continue token is
+// the index of the next pod. The token expires after the given number of pages.
+type fakePodLister struct {
+	count   int
+	expires int
+	calls   []metav1.ListOptions
+}
+
+func (l *fakePodLister) List(_ context.Context, opts metav1.ListOptions) (*corev1.PodList, error) {
+	l.calls = append(l.calls, opts)
+	start := 0
+	if opts.Continue != "" {
+		if l.expires > 0 && len(l.calls) > l.expires {
+			return nil, apierrors.NewResourceExpired("continue token expired")
+		}
+		start, _ = strconv.Atoi(opts.Continue)
+	}
+	end := l.count
+	if opts.Limit > 0 && start+int(opts.Limit) < end {
+		end = start + int(opts.Limit)
+	}
+	list := &corev1.PodList{}
+	for i := start; i < end; i++ {
+		list.Items = append(list.Items, corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("pod%d", i), Namespace: "default"}})
+	}
+	if end < l.count {
+		list.Continue = strconv.Itoa(end)
+	}
+	return list, nil
+}
+
+func TestListAll(t *testing.T) {
+	tests := []struct {
+		name    string
+		count   int
+		expires int
+		opts    metav1.ListOptions
+		items   int
+		calls   int
+	}{
+		{"empty", 0, 0, metav1.ListOptions{}, 0, 1},
+		{"one-page", 3, 0, metav1.ListOptions{}, 3, 1},
+		{"pages", 1200, 0, metav1.ListOptions{}, 1200, 3},
+		{"limit", 1200, 0, metav1.ListOptions{Limit: 100}, 100, 1},
+		{"expired", 1200, 1, metav1.ListOptions{}, 1200, 3},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			l := &fakePodLister{count: v.count, expires: v.expires}
+			list, err := listAll(context.TODO(), l.List, v.opts)
+			require.NoError(t, err)
+			assert.Len(t, list.Items, v.items)
+			assert.Equal(t, v.opts.Limit > 0, list.Continue != "")
+			assert.Len(t, l.calls, v.calls)
+		})
+	}
+}
+
+func TestListUnstructuredPages(t *testing.T) {
+	widget := schema.GroupVersionKind{Group: "kube.shipengqi.io", Version: "v1", Kind: "Widget"}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(widget, meta.RESTScopeNamespace)
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		widget.GroupVersion().WithResource("widgets"): "WidgetList",
+	})
+	// pages of 2 widgets, the continue token is the index of the next widget
+	var calls []metav1.ListOptions
+	client.PrependReactor("list", "widgets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		opts := action.(k8stesting.ListActionImpl).ListOptions
+		calls = append(calls, opts)
+		start, _ := strconv.Atoi(opts.Continue)
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(widget.GroupVersion().WithKind("WidgetList"))
+		for i := start; i < 5 && i < start+int(opts.Limit); i++ {
+			item := unstructured.Unstructured{}
+			item.SetGroupVersionKind(widget)
+			item.SetNamespace("default")
+			item.SetName(fmt.Sprintf("widget%d", i))
+			list.Items = append(list.Items, item)
+		}
+		if next := start + int(opts.Limit); next < 5 {
+			list.SetContinue(strconv.Itoa(next))
+		}
+		return true, list, nil
+	})
+	cli := &Client{dynamic: client, mapper: staticRESTMapper{mapper}}
+
+	size := DefaultPageSize
+	DefaultPageSize = 2
+	defer func() { DefaultPageSize = size }()
+
+	list, err := cli.ListUnstructured(context.TODO(), widget, "default")
+	require.NoError(t, err)
+	require.Len(t, list.Items, 5)
+	assert.Equal(t, "widget4", list.Items[4].GetName())
+	assert.Empty(t, list.GetContinue())
+	assert.Len(t, calls, 3)
+}
+
+func TestListItems(t *testing.T) {
+	t.Run("pages", func(t *testing.T) {
+		l := &fakePodLister{count: 25}
+		var names []string
+		for pod, err := range listItems[*corev1.Pod](context.TODO(), l.List, metav1.ListOptions{Limit: 10}) {
+			require.NoError(t, err)
+			names = append(names, pod.Name)
+		}
+		assert.Len(t, names, 25)
+		assert.Len(t, l.calls, 3)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		l := &fakePodLister{count: 25, expires: 1}
+		seen := map[string]int{}
+		for pod, err := range listItems[*corev1.Pod](context.TODO(), l.List, metav1.ListOptions{Limit: 10}) {
+			require.NoError(t, err)
+			seen[pod.Name]++
+		}
+		assert.Len(t, seen, 25)
+		for name, n := range seen {
+			assert.Equal(t, 1, n, name)
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		l := &fakePodLister{count: 25}
+		for range listItems[*corev1.Pod](context.TODO(), l.List, metav1.ListOptions{Limit: 10}) {
+			break
+		}
+		assert.Len(t, l.calls, 1)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		list := func(context.Context, metav1.ListOptions) (*corev1.PodList, error) {
+			return nil, errors.New("forbidden")
+		}
+		for _, err := range listItems[*corev1.Pod](context.TODO(), list, metav1.ListOptions{}) {
+			assert.EqualError(t, err, "forbidden")
+		}
+	})
+}
@@ -4,17 +4,21 @@ import (
 	"context"
 
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // GetPods returns a PodList.
 func (c *Client) GetPods(ctx context.Context, namespace string, label ...string) (*corev1.PodList, error) {
-	return c.client.CoreV1().Pods(namespace).List(ctx, listOptions(label))
+	return c.Pods(namespace).List(ctx, labelListOptions(label))
+}
+
+// GetPodsWithOptions is like GetPods, but with the given ListOption.
+func (c *Client) GetPodsWithOptions(ctx context.Context, namespace string, opts ...ListOption) (*corev1.PodList, error) {
+	return listWithOptions(ctx, c.Pods(namespace).List, opts)
 }
 
 // GetPod returns a Pod with the given name.
 func (c *Client) GetPod(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
-	return c.client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	return c.Pods(namespace).Get(ctx, name)
 }
 
 // CreatePod creates a new Pod.
@@ -22,10 +26,10 @@ func (c *Client) CreatePod(ctx context.Context, pod *corev1.Pod) (*corev1.Pod, e
 	if len(pod.Namespace) == 0 {
 		return nil, ErrorMissingNamespace
 	}
-	return c.client.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+	return c.Pods(pod.Namespace).Create(ctx, pod)
 }
 
 // DeletePod deletes a Pod.
-func (c *Client) DeletePod(ctx context.Context, namespace, name string) error {
-	return c.client.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+func (c *Client) DeletePod(ctx context.Context, namespace, name string, opts ...DeleteOption) error {
+	return c.Pods(namespace).Delete(ctx, name, opts...)
 }
@@ -0,0 +1,141 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/dynamic"
+)
+
+// PruneSetLabel is the label of objects applied WithPrune, its value is the ID of the set.
+// It is owned by this module, unlike the labels of kubectl ApplySets, which require a parent object.
+const PruneSetLabel = "kube.shipengqi.io/prune-set"
+
+// DefaultPruneKinds is the list of kinds pruned WithPrune if none is given,
+// it is like the default allowlist of 'kubectl apply --prune'.
+var DefaultPruneKinds = []schema.GroupVersionKind{
+	{Version: "v1", Kind: "ConfigMap"},
+	{Version: "v1", Kind: "Endpoints"},
+	{Version: "v1", Kind: "Namespace"},
+	{Version: "v1", Kind: "PersistentVolumeClaim"},
+	{Version: "v1", Kind: "PersistentVolume"},
+	{Version: "v1", Kind: "Pod"},
+	{Version: "v1", Kind: "ReplicationController"},
+	{Version: "v1", Kind: "Secret"},
+	{Version: "v1", Kind: "Service"},
+	{Group: "batch", Version: "v1", Kind: "Job"},
+	{Group: "batch", Version: "v1", Kind: "CronJob"},
+	{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+	{Group: "apps", Version: "v1", Kind: "DaemonSet"},
+	{Group: "apps", Version: "v1", Kind: "Deployment"},
+	{Group: "apps", Version: "v1", Kind: "ReplicaSet"},
+	{Group: "apps", Version: "v1", Kind: "StatefulSet"},
+}
+
+// prune deletes the live objects of the apply set that are not in the given results of Apply.
+// Like 'kubectl apply --prune', live objects of namespaced kinds are listed only in the namespaces
+// of the applied objects, so that namespace-scoped permissions are enough, and live objects of
+// cluster-scoped kinds in the whole cluster. Kinds not served by the cluster are ignored.
+func (c *Client) prune(ctx context.Context, mapper meta.ResettableRESTMapper, applied Results, opts *applyOptions) (Results, error) {
+	keep := make(map[string]bool, len(applied))
+	var namespaces []string
+	for _, r := range applied {
+		keep[pruneKey(r.GroupVersionKind.GroupKind(), r.Namespace, r.Name)] = true
+		if r.Namespace != "" && !slices.Contains(namespaces, r.Namespace) {
+			namespaces = append(namespaces, r.Namespace)
+		}
+	}
+	slices.Sort(namespaces)
+	dyn, err := c.DialDynamic()
+	if err != nil {
+		return nil, err
+	}
+	selector := labels.Set{PruneSetLabel: opts.pruneSetID}.String()
+
+	var objs []runtime.Object
+	for _, gvk := range opts.pruneKinds {
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		lists := []dynamic.ResourceInterface{dyn.Resource(mapping.Resource)}
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			lists = lists[:0]
+			for _, ns := range namespaces {
+				lists = append(lists, dyn.Resource(mapping.Resource).Namespace(ns))
+			}
+		}
+		for _, ri := range lists {
+			list, err := listAll(ctx, ri.List, metav1.ListOptions{LabelSelector: selector})
+			if err != nil {
+				return nil, err
+			}
+			for i := range list.Items {
+				obj := &list.Items[i]
+				if keep[pruneKey(gvk.GroupKind(), obj.GetNamespace(), obj.GetName())] {
+					continue
+				}
+				obj.SetGroupVersionKind(gvk)
+				objs = append(objs, obj)
+			}
+		}
+	}
+	sortObjects(objs, OperationDelete)
+
+	// The namespace of live objects is kept as it is.
+	riOpts := &applyOptions{}
+	return executeEach(ctx, objs, opts, func(obj runtime.Object) Result {
+		result := newResult(obj)
+		live, err := manifestObject(obj)
+		if err != nil {
+			return result.failed(err)
+		}
+		ri, err := c.resourceInterface(mapper, live, riOpts)
+		if err != nil {
+			return result.failed(err)
+		}
+		err = ri.Delete(ctx, result.Name, metav1.DeleteOptions{DryRun: opts.dryRunAll()})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return result.failed(err)
+		}
+		result.Action = ActionPruned
+		return result
+	})
+}
+
+// validatePruneSetID returns an error if the given ID of the set is not a valid label value.
+func validatePruneSetID(setID string) error {
+	if errs := validation.IsValidLabelValue(setID); len(errs) > 0 {
+		return fmt.Errorf("invalid prune set ID %q: %s", setID, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// setPruneLabel labels the given object with the apply set of the given options.
+func setPruneLabel(obj metav1.Object, opts *applyOptions) {
+	if opts.pruneSetID == "" {
+		return
+	}
+	lbls := obj.GetLabels()
+	if lbls == nil {
+		lbls = map[string]string{}
+	}
+	lbls[PruneSetLabel] = opts.pruneSetID
+	obj.SetLabels(lbls)
+}
+
+func pruneKey(gk schema.GroupKind, namespace, name string) string {
+	return gk.String() + "/" + objectKey(namespace, name)
+}
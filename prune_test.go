@@ -0,0 +1,137 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestSetPruneLabel(t *testing.T) {
+	obj := &metav1.ObjectMeta{Labels: map[string]string{"app": "nginx"}}
+	setPruneLabel(obj, newApplyOptions())
+	assert.Equal(t, map[string]string{"app": "nginx"}, obj.Labels)
+
+	o := newApplyOptions(WithPrune("kube-set"))
+	assert.Equal(t, DefaultPruneKinds, o.pruneKinds)
+	setPruneLabel(obj, o)
+	assert.Equal(t, map[string]string{"app": "nginx", PruneSetLabel: "kube-set"}, obj.Labels)
+
+	require.NoError(t, validatePruneSetID("kube-set"))
+	require.NoError(t, validatePruneSetID(""))
+	assert.ErrorContains(t, validatePruneSetID("kube set/1"), `invalid prune set ID "kube set/1"`)
+	_, err := (&Client{}).ApplyObjects(context.TODO(), nil, WithPrune(strings.Repeat("a", 64)))
+	assert.ErrorContains(t, err, "must be no more than 63 bytes")
+
+	cm := schema.GroupKind{Kind: "ConfigMap"}
+	assert.Equal(t, "ConfigMap/default/cm1", pruneKey(cm, "default", "cm1"))
+	assert.NotEqual(t, pruneKey(cm, "default", "cm1"), pruneKey(schema.GroupKind{Kind: "Secret"}, "default", "cm1"))
+}
+
+func TestPrune(t *testing.T) {
+	cmGVK := corev1.SchemeGroupVersion.WithKind("ConfigMap")
+	nsGVK := corev1.SchemeGroupVersion.WithKind("Namespace")
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(cmGVK, meta.RESTScopeNamespace)
+	mapper.Add(nsGVK, meta.RESTScopeRoot)
+	setLabels := map[string]string{PruneSetLabel: "kube-set"}
+	client := dynamicfake.NewSimpleDynamicClient(scheme.Scheme,
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kube-cm1", Namespace: "default", Labels: setLabels}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kube-cm2", Namespace: "default", Labels: setLabels}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kube-cm3", Namespace: "other", Labels: setLabels}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-ns", Labels: setLabels}},
+	)
+	// namespace-scoped permissions
+	client.PrependReactor("list", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() == "" {
+			return true, nil, apierrors.NewForbidden(corev1.Resource("configmaps"), "", errors.New("cluster-wide list"))
+		}
+		return false, nil, nil
+	})
+	cli := &Client{dynamic: client, mapper: staticRESTMapper{mapper}}
+
+	applied := Results{{GroupVersionKind: cmGVK, Namespace: "default", Name: "kube-cm1", Action: ActionUnchanged}}
+	results, err := cli.prune(context.TODO(), cli.mapper, applied, newApplyOptions(WithPrune("kube-set", cmGVK, nsGVK)))
+	require.NoError(t, err)
+	var pruned []string
+	for _, r := range results {
+		assert.Equal(t, ActionPruned, r.Action)
+		pruned = append(pruned, objectKey(r.Namespace, r.Name))
+	}
+	assert.ElementsMatch(t, []string{"default/kube-cm2", "kube-ns"}, pruned)
+
+	// the namespace not applied to is left untouched
+	_, err = cli.GetUnstructured(context.TODO(), cmGVK, "other", "kube-cm3")
+	require.NoError(t, err)
+}
+
+func TestClientApplyPrune(t *testing.T) {
+	both := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: kube-cm-prune1
+  namespace: default
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: kube-cm-prune2
+  namespace: default
+`)
+	one := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: kube-cm-prune1
+  namespace: default
+`)
+	prune := WithPrune("kube-prune-test")
+
+	t.Run("apply", func(t *testing.T) {
+		results, err := mockcli.ApplyBytes(context.TODO(), both, prune)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		cm, err := mockcli.GetConfigMap(context.TODO(), "default", "kube-cm-prune2")
+		require.NoError(t, err)
+		assert.Equal(t, "kube-prune-test", cm.Labels[PruneSetLabel])
+	})
+
+	t.Run("prune:dry-run", func(t *testing.T) {
+		results, err := mockcli.ApplyBytes(context.TODO(), one, prune, WithDryRun())
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, ActionPruned, results[1].Action)
+		_, err = mockcli.GetConfigMap(context.TODO(), "default", "kube-cm-prune2")
+		require.NoError(t, err)
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		results, err := mockcli.ApplyBytes(context.TODO(), one, prune)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "configmap/kube-cm-prune2 pruned", results[1].String())
+		_, err = mockcli.GetConfigMap(context.TODO(), "default", "kube-cm-prune2")
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("prune:allowlist", func(t *testing.T) {
+		results, err := mockcli.ApplyObjects(context.TODO(), nil, WithPrune("kube-prune-test", schema.GroupVersionKind{Version: "v1", Kind: "Secret"}))
+		require.NoError(t, err)
+		assert.Empty(t, results)
+
+		results, err = mockcli.DeleteBytes(context.TODO(), one)
+		require.NoError(t, err)
+		assert.Equal(t, ActionDeleted, results[0].Action)
+	})
+}
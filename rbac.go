@@ -5,17 +5,21 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // GetServiceAccounts returns a ServiceAccountList.
 func (c *Client) GetServiceAccounts(ctx context.Context, namespace string, label ...string) (*corev1.ServiceAccountList, error) {
-	return c.client.CoreV1().ServiceAccounts(namespace).List(ctx, listOptions(label))
+	return c.ServiceAccounts(namespace).List(ctx, labelListOptions(label))
+}
+
+// GetServiceAccountsWithOptions is like GetServiceAccounts, but with the given ListOption.
+func (c *Client) GetServiceAccountsWithOptions(ctx context.Context, namespace string, opts ...ListOption) (*corev1.ServiceAccountList, error) {
+	return listWithOptions(ctx, c.ServiceAccounts(namespace).List, opts)
 }
 
 // GetServiceAccount returns a ServiceAccount with the given name.
 func (c *Client) GetServiceAccount(ctx context.Context, namespace, name string) (*corev1.ServiceAccount, error) {
-	return c.client.CoreV1().ServiceAccounts(namespace).Get(ctx, name, metav1.GetOptions{})
+	return c.ServiceAccounts(namespace).Get(ctx, name)
 }
 
 // CreateServiceAccount creates a new ServiceAccount.
@@ -23,42 +27,52 @@ func (c *Client) CreateServiceAccount(ctx context.Context, sa *corev1.ServiceAcc
 	if len(sa.Namespace) == 0 {
 		return nil, ErrorMissingNamespace
 	}
-	return c.client.CoreV1().ServiceAccounts(sa.Namespace).Create(ctx, sa, metav1.CreateOptions{})
+	return c.ServiceAccounts(sa.Namespace).Create(ctx, sa)
 }
 
 // DeleteServiceAccount deletes a ServiceAccount.
-func (c *Client) DeleteServiceAccount(ctx context.Context, namespace, name string) error {
-	return c.client.CoreV1().ServiceAccounts(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+func (c *Client) DeleteServiceAccount(ctx context.Context, namespace, name string, opts ...DeleteOption) error {
+	return c.ServiceAccounts(namespace).Delete(ctx, name, opts...)
 }
 
 // GetClusterRoles returns a ClusterRoleList.
 func (c *Client) GetClusterRoles(ctx context.Context, label ...string) (*rbacv1.ClusterRoleList, error) {
-	return c.client.RbacV1().ClusterRoles().List(ctx, listOptions(label))
+	return c.ClusterRoles().List(ctx, labelListOptions(label))
+}
+
+// GetClusterRolesWithOptions is like GetClusterRoles, but with the given ListOption.
+func (c *Client) GetClusterRolesWithOptions(ctx context.Context, opts ...ListOption) (*rbacv1.ClusterRoleList, error) {
+	return listWithOptions(ctx, c.ClusterRoles().List, opts)
 }
 
 // GetClusterRole returns a ClusterRole with the given name.
 func (c *Client) GetClusterRole(ctx context.Context, name string) (*rbacv1.ClusterRole, error) {
-	return c.client.RbacV1().ClusterRoles().Get(ctx, name, metav1.GetOptions{})
+	return c.ClusterRoles().Get(ctx, name)
 }
 
 // CreateClusterRole creates a new ClusterRole.
 func (c *Client) CreateClusterRole(ctx context.Context, role *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
-	return c.client.RbacV1().ClusterRoles().Create(ctx, role, metav1.CreateOptions{})
+	return c.ClusterRoles().Create(ctx, role)
 }
 
 // DeleteClusterRole deletes a ClusterRole.
-func (c *Client) DeleteClusterRole(ctx context.Context, name string) error {
-	return c.client.RbacV1().ClusterRoles().Delete(ctx, name, metav1.DeleteOptions{})
+func (c *Client) DeleteClusterRole(ctx context.Context, name string, opts ...DeleteOption) error {
+	return c.ClusterRoles().Delete(ctx, name, opts...)
 }
 
 // GetRoles returns a RoleList.
 func (c *Client) GetRoles(ctx context.Context, namespace string, label ...string) (*rbacv1.RoleList, error) {
-	return c.client.RbacV1().Roles(namespace).List(ctx, listOptions(label))
+	return c.Roles(namespace).List(ctx, labelListOptions(label))
+}
+
+// GetRolesWithOptions is like GetRoles, but with the given ListOption.
+func (c *Client) GetRolesWithOptions(ctx context.Context, namespace string, opts ...ListOption) (*rbacv1.RoleList, error) {
+	return listWithOptions(ctx, c.Roles(namespace).List, opts)
 }
 
 // GetRole returns a Role with the given name.
 func (c *Client) GetRole(ctx context.Context, namespace, name string) (*rbacv1.Role, error) {
-	return c.client.RbacV1().Roles(namespace).Get(ctx, name, metav1.GetOptions{})
+	return c.Roles(namespace).Get(ctx, name)
 }
 
 // CreateRole create a new Role.
@@ -66,10 +80,10 @@ func (c *Client) CreateRole(ctx context.Context, role *rbacv1.Role) (*rbacv1.Rol
 	if len(role.Namespace) == 0 {
 		return nil, ErrorMissingNamespace
 	}
-	return c.client.RbacV1().Roles(role.Namespace).Create(ctx, role, metav1.CreateOptions{})
+	return c.Roles(role.Namespace).Create(ctx, role)
 }
 
 // DeleteRole deletes a Role.
-func (c *Client) DeleteRole(ctx context.Context, namespace, name string) error {
-	return c.client.RbacV1().Roles(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+func (c *Client) DeleteRole(ctx context.Context, namespace, name string, opts ...DeleteOption) error {
+	return c.Roles(namespace).Delete(ctx, name, opts...)
 }
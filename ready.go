@@ -0,0 +1,392 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+var readyPollInterval = time.Second
+
+// ErrNeverReady is matched by the errors of objects that will never be ready, e.g. a failed Job.
+var ErrNeverReady = errors.New("will never be ready")
+
+// neverReadyError is the error of an object that will never be ready, waiting for it stops early.
+type neverReadyError struct {
+	msg string
+}
+
+func (e *neverReadyError) Error() string {
+	return e.msg
+}
+
+// Is reports whether the target is ErrNeverReady.
+func (e *neverReadyError) Is(target error) bool {
+	return target == ErrNeverReady
+}
+
+func neverReady(format string, args ...interface{}) error {
+	return &neverReadyError{msg: fmt.Sprintf(format, args...)}
+}
+
+// ReadyStatus is the readiness of an object.
+type ReadyStatus struct {
+	GroupVersionKind schema.GroupVersionKind
+	Namespace        string
+	Name             string
+	Ready            bool
+	// Message describes why the object is not ready.
+	Message string
+}
+
+// String returns the status like the output of kubectl, e.g. "deployment.apps/nginx not ready: 1 of 2 updated replicas are available".
+func (s ReadyStatus) String() string {
+	kind := strings.ToLower(s.GroupVersionKind.Kind)
+	if s.GroupVersionKind.Group != "" {
+		kind = kind + "." + s.GroupVersionKind.Group
+	}
+	if s.Ready {
+		return fmt.Sprintf("%s/%s ready", kind, s.Name)
+	}
+	return fmt.Sprintf("%s/%s not ready: %s", kind, s.Name, s.Message)
+}
+
+// ReadyStatuses is a list of ReadyStatus.
+type ReadyStatuses []ReadyStatus
+
+// NotReady returns the statuses of objects that are not ready.
+func (ss ReadyStatuses) NotReady() ReadyStatuses {
+	var filtered ReadyStatuses
+	for _, s := range ss {
+		if !s.Ready {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+// String returns the summary of statuses, one status per line.
+func (ss ReadyStatuses) String() string {
+	lines := make([]string, 0, len(ss))
+	for _, s := range ss {
+		lines = append(lines, s.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// NotReadyError is returned when objects are not ready in time, or will never be ready.
+type NotReadyError struct {
+	// Statuses is the last status of all objects waited for.
+	Statuses ReadyStatuses
+
+	err error
+}
+
+func (e *NotReadyError) Error() string {
+	notReady := e.Statuses.NotReady()
+	msgs := make([]string, 0, len(notReady))
+	for _, s := range notReady {
+		msgs = append(msgs, fmt.Sprintf("%s %s: %s", s.GroupVersionKind.Kind, objectKey(s.Namespace, s.Name), s.Message))
+	}
+	return fmt.Sprintf("%d object(s) not ready: %s", len(notReady), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the error that stopped the waiting, e.g. context.DeadlineExceeded
+// if the timeout expires.
+func (e *NotReadyError) Unwrap() error {
+	return e.err
+}
+
+// WaitForReady waits for the given objects to be ready, until the given timeout expires.
+// A zero timeout means no timeout other than the deadline of ctx.
+// A *NotReadyError reporting the objects that are not ready and why is returned if the
+// timeout expires, or if an object will never be ready, e.g. a failed Job, it then matches ErrNeverReady.
+// Other errors checking an object, e.g. a failed request, are reported in its status and retried.
+// The readiness of an object depends on its kind:
+//   - Deployment, StatefulSet, DaemonSet: the latest generation is observed and rolled out,
+//     all updated replicas are available.
+//   - Job: the job is complete.
+//   - PersistentVolumeClaim: the claim is bound.
+//   - Service: the load balancer ingress is assigned if the type is LoadBalancer.
+//   - CustomResourceDefinition: the definition is established.
+//   - Pod: the pod is ready, or has succeeded.
+//
+// Objects of other kinds are ready once they exist.
+//
+// Namespaced objects without metadata.namespace are looked up in the current namespace,
+// WithDefaultNamespace and WithNamespaceOverride are honored like in ApplyWithOptions.
+func (c *Client) WaitForReady(ctx context.Context, objs []runtime.Object, timeout time.Duration, opts ...ApplyOption) (ReadyStatuses, error) {
+	mapper, err := c.RESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	o := newApplyOptions(opts...)
+	if o.namespaceMode == namespaceUnset {
+		o.namespaceMode = namespaceDefault
+	}
+	if err = c.resolveNamespace(o); err != nil {
+		return nil, err
+	}
+	desired := make([]*unstructured.Unstructured, 0, len(objs))
+	for i := range objs {
+		obj, err := manifestObject(objs[i])
+		if err != nil {
+			return nil, err
+		}
+		desired = append(desired, obj)
+	}
+	return c.waitForReady(ctx, mapper, desired, o, timeout)
+}
+
+func (c *Client) waitForReady(ctx context.Context, mapper meta.ResettableRESTMapper, objs []*unstructured.Unstructured, opts *applyOptions, timeout time.Duration) (ReadyStatuses, error) {
+	statuses := make(ReadyStatuses, len(objs))
+	for i, obj := range objs {
+		statuses[i] = ReadyStatus{
+			GroupVersionKind: obj.GroupVersionKind(),
+			Namespace:        obj.GetNamespace(),
+			Name:             obj.GetName(),
+			Message:          "not checked",
+		}
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	err := wait.PollUntilContextCancel(ctx, readyPollInterval, true, func(ctx context.Context) (bool, error) {
+		done := true
+		for i, obj := range objs {
+			if statuses[i].Ready {
+				continue
+			}
+			ready, msg, err := c.checkReady(ctx, mapper, obj, opts)
+			if err != nil {
+				statuses[i].Message = err.Error()
+				if errors.Is(err, ErrNeverReady) {
+					return false, err
+				}
+				// transient errors, e.g. a timeout or an unknown kind, are retried until the timeout
+				done = false
+				continue
+			}
+			statuses[i].Namespace = obj.GetNamespace()
+			statuses[i].Ready, statuses[i].Message = ready, msg
+			done = done && ready
+		}
+		return done, nil
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		return statuses, &NotReadyError{Statuses: statuses, err: err}
+	}
+	return statuses, nil
+}
+
+// checkReady gets the live object of the given object and reports whether it is ready.
+func (c *Client) checkReady(ctx context.Context, mapper meta.ResettableRESTMapper, obj *unstructured.Unstructured, opts *applyOptions) (bool, string, error) {
+	ri, err := c.resourceInterface(mapper, obj, opts)
+	if err != nil {
+		return false, "", err
+	}
+	live, err := getObject(ctx, ri, obj.GetName())
+	if err != nil || live == nil {
+		return false, "not found", err
+	}
+	return objectReady(live)
+}
+
+// objectReady reports whether the given live object is ready, and why it is not ready.
+// An error matching ErrNeverReady is returned if the object will never be ready.
+func objectReady(obj *unstructured.Unstructured) (bool, string, error) {
+	gvk := obj.GroupVersionKind()
+	switch (schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind}) {
+	case schema.GroupKind{Group: appsv1.GroupName, Kind: "Deployment"}:
+		d := &appsv1.Deployment{}
+		if err := fromUnstructured(obj, d); err != nil {
+			return false, "", err
+		}
+		return deploymentReady(d)
+	case schema.GroupKind{Group: appsv1.GroupName, Kind: "StatefulSet"}:
+		sts := &appsv1.StatefulSet{}
+		if err := fromUnstructured(obj, sts); err != nil {
+			return false, "", err
+		}
+		ready, msg := statefulSetReady(sts)
+		return ready, msg, nil
+	case schema.GroupKind{Group: appsv1.GroupName, Kind: "DaemonSet"}:
+		ds := &appsv1.DaemonSet{}
+		if err := fromUnstructured(obj, ds); err != nil {
+			return false, "", err
+		}
+		ready, msg := daemonSetReady(ds)
+		return ready, msg, nil
+	case schema.GroupKind{Group: batchv1.GroupName, Kind: "Job"}:
+		job := &batchv1.Job{}
+		if err := fromUnstructured(obj, job); err != nil {
+			return false, "", err
+		}
+		return jobReady(job)
+	case schema.GroupKind{Kind: "PersistentVolumeClaim"}:
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := fromUnstructured(obj, pvc); err != nil {
+			return false, "", err
+		}
+		if pvc.Status.Phase != corev1.ClaimBound {
+			return false, fmt.Sprintf("claim is %s", pvc.Status.Phase), nil
+		}
+		return true, "", nil
+	case schema.GroupKind{Kind: "Service"}:
+		svc := &corev1.Service{}
+		if err := fromUnstructured(obj, svc); err != nil {
+			return false, "", err
+		}
+		if svc.Spec.Type == corev1.ServiceTypeLoadBalancer && len(svc.Status.LoadBalancer.Ingress) == 0 {
+			return false, "load balancer ingress is not assigned", nil
+		}
+		return true, "", nil
+	case schema.GroupKind{Kind: "Pod"}:
+		pod := &corev1.Pod{}
+		if err := fromUnstructured(obj, pod); err != nil {
+			return false, "", err
+		}
+		return podReady(pod)
+	case schema.GroupKind{Group: crdGroup, Kind: crdKind}:
+		established, err := crdEstablished(obj)
+		if err != nil || established {
+			return established, "", err
+		}
+		return false, "not established", nil
+	}
+	return true, "", nil
+}
+
+func deploymentReady(d *appsv1.Deployment) (bool, string, error) {
+	if d.Status.ObservedGeneration < d.Generation {
+		return false, "latest generation is not observed", nil
+	}
+	for _, cond := range d.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Reason == "ProgressDeadlineExceeded" {
+			return false, "", neverReady("progress deadline exceeded: %s", cond.Message)
+		}
+	}
+	replicas := replicasOrDefault(d.Spec.Replicas)
+	switch {
+	case d.Status.UpdatedReplicas < replicas:
+		return false, fmt.Sprintf("%d of %d replicas are updated", d.Status.UpdatedReplicas, replicas), nil
+	case d.Status.Replicas > d.Status.UpdatedReplicas:
+		return false, fmt.Sprintf("%d old replicas are pending termination", d.Status.Replicas-d.Status.UpdatedReplicas), nil
+	case d.Status.AvailableReplicas < d.Status.UpdatedReplicas:
+		return false, fmt.Sprintf("%d of %d updated replicas are available", d.Status.AvailableReplicas, d.Status.UpdatedReplicas), nil
+	}
+	return true, "", nil
+}
+
+func statefulSetReady(sts *appsv1.StatefulSet) (bool, string) {
+	if sts.Status.ObservedGeneration < sts.Generation {
+		return false, "latest generation is not observed"
+	}
+	if sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return true, ""
+	}
+	replicas := replicasOrDefault(sts.Spec.Replicas)
+	if sts.Status.ReadyReplicas < replicas {
+		return false, fmt.Sprintf("%d of %d replicas are ready", sts.Status.ReadyReplicas, replicas)
+	}
+	if ru := sts.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil && *ru.Partition > 0 {
+		if expected := replicas - *ru.Partition; sts.Status.UpdatedReplicas < expected {
+			return false, fmt.Sprintf("%d of %d partitioned replicas are updated", sts.Status.UpdatedReplicas, expected)
+		}
+		return true, ""
+	}
+	if sts.Status.UpdateRevision != sts.Status.CurrentRevision {
+		return false, fmt.Sprintf("%d of %d replicas are updated to revision %s", sts.Status.UpdatedReplicas, replicas, sts.Status.UpdateRevision)
+	}
+	return true, ""
+}
+
+func daemonSetReady(ds *appsv1.DaemonSet) (bool, string) {
+	if ds.Status.ObservedGeneration < ds.Generation {
+		return false, "latest generation is not observed"
+	}
+	desired := ds.Status.DesiredNumberScheduled
+	switch {
+	case ds.Status.UpdatedNumberScheduled < desired:
+		return false, fmt.Sprintf("%d of %d pods are updated", ds.Status.UpdatedNumberScheduled, desired)
+	case ds.Status.NumberAvailable < desired:
+		return false, fmt.Sprintf("%d of %d pods are available", ds.Status.NumberAvailable, desired)
+	}
+	return true, ""
+}
+
+func jobReady(job *batchv1.Job) (bool, string, error) {
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return true, "", nil
+		case batchv1.JobFailed:
+			return false, "", neverReady("job failed: %s", cond.Message)
+		}
+	}
+	return false, fmt.Sprintf("%d pods are active, %d succeeded", job.Status.Active, job.Status.Succeeded), nil
+}
+
+func podReady(pod *corev1.Pod) (bool, string, error) {
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		return true, "", nil
+	case corev1.PodFailed:
+		return false, "", neverReady("pod failed: %s", pod.Status.Message)
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
+			return true, "", nil
+		}
+	}
+	return false, fmt.Sprintf("pod is %s", pod.Status.Phase), nil
+}
+
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+func fromUnstructured(obj *unstructured.Unstructured, into interface{}) error {
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, into)
+}
+
+// readyObjects returns objects referring to the applied objects of the given results.
+func readyObjects(results Results) []*unstructured.Unstructured {
+	objs := make([]*unstructured.Unstructured, 0, len(results))
+	for _, r := range results {
+		switch r.Action {
+		case ActionCreated, ActionConfigured, ActionUnchanged:
+		default:
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(r.GroupVersionKind)
+		obj.SetNamespace(r.Namespace)
+		obj.SetName(r.Name)
+		objs = append(objs, obj)
+	}
+	return objs
+}
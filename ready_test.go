@@ -0,0 +1,233 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+func TestObjectReady(t *testing.T) {
+	tests := []struct {
+		name     string
+		obj      runtime.Object
+		expected bool
+		msg      string
+		err      string
+	}{
+		{"deployment:generation", &appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Status:     appsv1.DeploymentStatus{ObservedGeneration: 1},
+		}, false, "latest generation is not observed", ""},
+		{"deployment:available", &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			Spec:     appsv1.DeploymentSpec{Replicas: ptr.To[int32](2)},
+			Status:   appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 1},
+		}, false, "1 of 2 updated replicas are available", ""},
+		{"deployment:ready", &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			Status:   appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+		}, true, "", ""},
+		{"deployment:deadline", &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			Status: appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentProgressing, Reason: "ProgressDeadlineExceeded"},
+			}},
+		}, false, "", "progress deadline exceeded"},
+		{"statefulset:revision", &appsv1.StatefulSet{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"},
+			Status:   appsv1.StatefulSetStatus{ReadyReplicas: 1, CurrentRevision: "r1", UpdateRevision: "r2"},
+		}, false, "0 of 1 replicas are updated to revision r2", ""},
+		{"daemonset:available", &appsv1.DaemonSet{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DaemonSet"},
+			Status:   appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 3},
+		}, true, "", ""},
+		{"job:failed", &batchv1.Job{
+			TypeMeta: metav1.TypeMeta{APIVersion: "batch/v1", Kind: "Job"},
+			Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "backoff limit exceeded"},
+			}},
+		}, false, "", "backoff limit exceeded"},
+		{"pvc:pending", &corev1.PersistentVolumeClaim{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"},
+			Status:   corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+		}, false, "claim is Pending", ""},
+		{"service:loadbalancer", &corev1.Service{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			Spec:     corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		}, false, "load balancer ingress is not assigned", ""},
+		{"configmap", &corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		}, true, "", ""},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			obj, err := manifestObject(v.obj)
+			require.NoError(t, err)
+			// manifestObject drops the status
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(v.obj)
+			require.NoError(t, err)
+			obj.Object["status"] = content["status"]
+
+			ready, msg, err := objectReady(obj)
+			if v.err != "" {
+				assert.ErrorContains(t, err, v.err)
+				assert.ErrorIs(t, err, ErrNeverReady)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, v.expected, ready)
+			assert.Equal(t, v.msg, msg)
+		})
+	}
+}
+
+func TestWaitForReady(t *testing.T) {
+	interval := readyPollInterval
+	readyPollInterval = 10 * time.Millisecond
+	defer func() { readyPollInterval = interval }()
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	cli := &Client{
+		dynamic: dynamicfake.NewSimpleDynamicClient(scheme.Scheme,
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kube-cm1", Namespace: "kube-system"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kube-cm1", Namespace: "kube-current"}},
+		),
+		mapper: staticRESTMapper{mapper},
+	}
+	// without metadata.namespace, like the output of GetObjects
+	objs := []runtime.Object{&corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "kube-cm1"},
+	}}
+
+	statuses, err := cli.WaitForReady(context.TODO(), objs, time.Second, WithDefaultNamespace("kube-system"))
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.True(t, statuses[0].Ready)
+	assert.Equal(t, "kube-system", statuses[0].Namespace)
+
+	// the current namespace of the kubeconfig
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://127.0.0.1:6443
+  name: kube
+contexts:
+- context:
+    cluster: kube
+    namespace: kube-current
+  name: kube
+current-context: kube
+`), 0o600))
+	flags := genericclioptions.NewConfigFlags(false)
+	flags.KubeConfig = &kubeconfig
+	cli.cfg = NewConfig(flags)
+	statuses, err = cli.WaitForReady(context.TODO(), objs, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "kube-current", statuses[0].Namespace)
+
+	t.Run("transient-error", func(t *testing.T) {
+		client := dynamicfake.NewSimpleDynamicClient(scheme.Scheme,
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kube-cm1", Namespace: "default"}})
+		failures := 2
+		client.PrependReactor("get", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if failures == 0 {
+				return false, nil, nil
+			}
+			failures--
+			return true, nil, apierrors.NewTimeoutError("request timeout", 1)
+		})
+		cli := &Client{dynamic: client, mapper: staticRESTMapper{mapper}}
+
+		statuses, err := cli.WaitForReady(context.TODO(), objs, time.Second, WithDefaultNamespace("default"))
+		require.NoError(t, err)
+		assert.True(t, statuses[0].Ready)
+		assert.Zero(t, failures)
+	})
+
+	t.Run("never-ready", func(t *testing.T) {
+		jobMapper := meta.NewDefaultRESTMapper(nil)
+		jobMapper.Add(batchv1.SchemeGroupVersion.WithKind("Job"), meta.RESTScopeNamespace)
+		job := &batchv1.Job{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "batch/v1", Kind: "Job"},
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-job", Namespace: "default"},
+			Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "backoff limit exceeded"},
+			}},
+		}
+		cli := &Client{
+			dynamic: dynamicfake.NewSimpleDynamicClient(scheme.Scheme, job),
+			mapper:  staticRESTMapper{jobMapper},
+		}
+
+		start := time.Now()
+		_, err := cli.WaitForReady(context.TODO(), []runtime.Object{job}, time.Minute, WithDefaultNamespace("default"))
+		var nre *NotReadyError
+		require.ErrorAs(t, err, &nre)
+		assert.ErrorIs(t, err, ErrNeverReady)
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, "job failed: backoff limit exceeded", nre.Statuses[0].Message)
+	})
+}
+
+func TestClientApplyWait(t *testing.T) {
+	files := []string{"testdata/nginx.yaml"}
+
+	t.Run("apply:wait", func(t *testing.T) {
+		_, err := mockcli.ApplyWithResults(context.TODO(), files, WithWait(3*time.Minute))
+		require.NoError(t, err)
+
+		objs, err := GetObjects(files...)
+		require.NoError(t, err)
+		statuses, err := mockcli.WaitForReady(context.TODO(), objs, time.Minute)
+		require.NoError(t, err)
+		assert.Empty(t, statuses.NotReady())
+	})
+
+	t.Run("apply:not-ready", func(t *testing.T) {
+		content := []byte(`apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: kube-pvc-notready
+  namespace: default
+spec:
+  storageClassName: kube-notexists
+  accessModes: ["ReadWriteOnce"]
+  resources:
+    requests:
+      storage: 1Mi
+`)
+		_, err := mockcli.ApplyBytes(context.TODO(), content, WithWait(3*time.Second))
+		var nre *NotReadyError
+		require.True(t, errors.As(err, &nre))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Len(t, nre.Statuses.NotReady(), 1)
+		assert.Equal(t, "kube-pvc-notready", nre.Statuses[0].Name)
+
+		_, err = mockcli.DeleteBytes(context.TODO(), content)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,114 @@
+package kube
+
+import (
+	"context"
+	"iter"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// TypedClient is the typed client of a built-in resource in client-go, e.g. the
+// PodInterface returned by kubernetes.Interface.CoreV1().Pods(namespace).
+// T is the type of objects, L the type of lists and C the type of apply configurations.
+type TypedClient[T, L runtime.Object, C any] interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+	List(ctx context.Context, opts metav1.ListOptions) (L, error)
+	Create(ctx context.Context, obj T, opts metav1.CreateOptions) (T, error)
+	Update(ctx context.Context, obj T, opts metav1.UpdateOptions) (T, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (T, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	Apply(ctx context.Context, cfg C, opts metav1.ApplyOptions) (T, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+}
+
+// Resource is the accessor of a resource, it provides the common operations of
+// the resource with a TypedClient. Accessors of the resources of the GA built-in
+// API groups are provided by Client, e.g. Client.Pods, others, e.g. beta versions,
+// can be created with NewResource:
+//
+//	k8s, _ := cli.Dial()
+//	candidates := kube.NewResource[*coordinationv1beta1.LeaseCandidate, *coordinationv1beta1.LeaseCandidateList, *coordinationv1beta1ac.LeaseCandidateApplyConfiguration](
+//		k8s.CoordinationV1beta1().LeaseCandidates("default"))
+type Resource[T, L runtime.Object, C any] struct {
+	client TypedClient[T, L, C]
+}
+
+// NewResource returns a Resource with the given TypedClient.
+func NewResource[T, L runtime.Object, C any](client TypedClient[T, L, C]) *Resource[T, L, C] {
+	return &Resource[T, L, C]{client: client}
+}
+
+// Client returns the TypedClient of the Resource.
+func (r *Resource[T, L, C]) Client() TypedClient[T, L, C] {
+	return r.client
+}
+
+// Get returns the object with the given name.
+func (r *Resource[T, L, C]) Get(ctx context.Context, name string) (T, error) {
+	return r.client.Get(ctx, name, metav1.GetOptions{})
+}
+
+// List returns the list of objects with the given ListOptions. Objects are listed page by page
+// with a page size of DefaultPageSize, unless ListOptions.Limit or ListOptions.Continue is set,
+// in which case only the requested page is returned. If the continue token of a page expires,
+// objects are relisted in full.
+func (r *Resource[T, L, C]) List(ctx context.Context, opts metav1.ListOptions) (L, error) {
+	return listAll(ctx, r.client.List, opts)
+}
+
+// Items returns an iterator over the objects listed with the given ListOptions, objects are
+// listed page by page when iterating, with a page size of ListOptions.Limit or DefaultPageSize.
+// The iteration stops after yielding an error.
+//
+//	for pod, err := range cli.Pods("").Items(ctx, metav1.ListOptions{}) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (r *Resource[T, L, C]) Items(ctx context.Context, opts metav1.ListOptions) iter.Seq2[T, error] {
+	return listItems[T](ctx, r.client.List, opts)
+}
+
+// Create creates a new object.
+func (r *Resource[T, L, C]) Create(ctx context.Context, obj T) (T, error) {
+	return r.client.Create(ctx, obj, metav1.CreateOptions{})
+}
+
+// Update updates the given object.
+func (r *Resource[T, L, C]) Update(ctx context.Context, obj T) (T, error) {
+	return r.client.Update(ctx, obj, metav1.UpdateOptions{})
+}
+
+// Patch patches the object with the given name, e.g. with types.StrategicMergePatchType.
+func (r *Resource[T, L, C]) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, subresources ...string) (T, error) {
+	return r.client.Patch(ctx, name, pt, data, metav1.PatchOptions{}, subresources...)
+}
+
+// Delete deletes the object with the given name.
+func (r *Resource[T, L, C]) Delete(ctx context.Context, name string, opts ...DeleteOption) error {
+	return deleteResource(ctx, r.client, name, opts)
+}
+
+// Apply applies the given apply configuration with server-side apply. Only the field manager of
+// WithServerSideApply, WithForceConflicts and WithDryRun are used, the field manager is
+// DefaultFieldManager by default.
+func (r *Resource[T, L, C]) Apply(ctx context.Context, cfg C, opts ...ApplyOption) (T, error) {
+	o := newApplyOptions(opts...)
+	if o.fieldManager == "" {
+		o.fieldManager = DefaultFieldManager
+	}
+	return r.client.Apply(ctx, cfg, metav1.ApplyOptions{
+		FieldManager: o.fieldManager,
+		Force:        o.forceConflicts,
+		DryRun:       o.dryRunAll(),
+	})
+}
+
+// Watch watches the objects with the given ListOptions.
+func (r *Resource[T, L, C]) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return r.client.Watch(ctx, opts)
+}
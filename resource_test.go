@@ -0,0 +1,79 @@
+package kube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	coordinationv1beta1 "k8s.io/api/coordination/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	coordinationv1ac "k8s.io/client-go/applyconfigurations/coordination/v1"
+	coordinationv1beta1ac "k8s.io/client-go/applyconfigurations/coordination/v1beta1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResource(t *testing.T) {
+	cli := &Client{client: fake.NewClientset()}
+	ctx := context.TODO()
+	pvcs := cli.PersistentVolumeClaims("default")
+
+	pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "pvc1", Namespace: "default"}}
+	_, err := pvcs.Create(ctx, pvc)
+	require.NoError(t, err)
+
+	got, err := pvcs.Get(ctx, "pvc1")
+	require.NoError(t, err)
+	assert.Equal(t, "pvc1", got.Name)
+
+	got.Labels = map[string]string{"app": "kube"}
+	_, err = pvcs.Update(ctx, got)
+	require.NoError(t, err)
+	list, err := pvcs.List(ctx, labelListOptions([]string{"app=kube"}))
+	require.NoError(t, err)
+	assert.Len(t, list.Items, 1)
+
+	got, err = pvcs.Patch(ctx, "pvc1", types.MergePatchType, []byte(`{"metadata":{"labels":{"app":"patched"}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "patched", got.Labels["app"])
+
+	w, err := pvcs.Watch(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	w.Stop()
+
+	err = pvcs.Delete(ctx, "pvc1", WithWaitForDeletion(0))
+	require.NoError(t, err)
+	_, err = pvcs.Get(ctx, "pvc1")
+	assert.True(t, apierrors.IsNotFound(err))
+
+	cm, err := cli.ConfigMaps("default").Apply(ctx, corev1ac.ConfigMap("cm1", "default").WithData(map[string]string{"a": "b"}))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "b"}, cm.Data)
+
+	leases := cli.Leases("default")
+	_, err = leases.Apply(ctx, coordinationv1ac.Lease("lease1", "default"), WithServerSideApply("kube-test"))
+	require.NoError(t, err)
+	lease, err := leases.Get(ctx, "lease1")
+	require.NoError(t, err)
+	assert.Equal(t, "lease1", lease.Name)
+
+	candidates := NewResource[*coordinationv1beta1.LeaseCandidate, *coordinationv1beta1.LeaseCandidateList, *coordinationv1beta1ac.LeaseCandidateApplyConfiguration](
+		cli.client.CoordinationV1beta1().LeaseCandidates("default"))
+	_, err = candidates.Apply(ctx, coordinationv1beta1ac.LeaseCandidate("candidate1", "default"), WithServerSideApply("kube-test"))
+	require.NoError(t, err)
+	candidate, err := candidates.Get(ctx, "candidate1")
+	require.NoError(t, err)
+	assert.Equal(t, "candidate1", candidate.Name)
+
+	webhook := &admissionregistrationv1.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "webhook1"}}
+	_, err = cli.ValidatingWebhookConfigurations().Create(ctx, webhook)
+	require.NoError(t, err)
+	webhooks, err := cli.ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, webhooks.Items, 1)
+}
@@ -0,0 +1,316 @@
+package kube
+
+import (
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	flowcontrolv1 "k8s.io/api/flowcontrol/v1"
+	networkv1 "k8s.io/api/networking/v1"
+	nodev1 "k8s.io/api/node/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	resourcev1 "k8s.io/api/resource/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	admissionregistrationv1ac "k8s.io/client-go/applyconfigurations/admissionregistration/v1"
+	appsv1ac "k8s.io/client-go/applyconfigurations/apps/v1"
+	autoscalingv2ac "k8s.io/client-go/applyconfigurations/autoscaling/v2"
+	batchv1ac "k8s.io/client-go/applyconfigurations/batch/v1"
+	certificatesv1ac "k8s.io/client-go/applyconfigurations/certificates/v1"
+	coordinationv1ac "k8s.io/client-go/applyconfigurations/coordination/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	discoveryv1ac "k8s.io/client-go/applyconfigurations/discovery/v1"
+	flowcontrolv1ac "k8s.io/client-go/applyconfigurations/flowcontrol/v1"
+	networkv1ac "k8s.io/client-go/applyconfigurations/networking/v1"
+	nodev1ac "k8s.io/client-go/applyconfigurations/node/v1"
+	policyv1ac "k8s.io/client-go/applyconfigurations/policy/v1"
+	rbacv1ac "k8s.io/client-go/applyconfigurations/rbac/v1"
+	resourcev1ac "k8s.io/client-go/applyconfigurations/resource/v1"
+	schedulingv1ac "k8s.io/client-go/applyconfigurations/scheduling/v1"
+	storagev1ac "k8s.io/client-go/applyconfigurations/storage/v1"
+)
+
+// Pods returns the Pod Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) Pods(namespace string) *Resource[*corev1.Pod, *corev1.PodList, *corev1ac.PodApplyConfiguration] {
+	return NewResource[*corev1.Pod, *corev1.PodList, *corev1ac.PodApplyConfiguration](c.client.CoreV1().Pods(namespace))
+}
+
+// Services returns the Service Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) Services(namespace string) *Resource[*corev1.Service, *corev1.ServiceList, *corev1ac.ServiceApplyConfiguration] {
+	return NewResource[*corev1.Service, *corev1.ServiceList, *corev1ac.ServiceApplyConfiguration](c.client.CoreV1().Services(namespace))
+}
+
+// ConfigMaps returns the ConfigMap Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) ConfigMaps(namespace string) *Resource[*corev1.ConfigMap, *corev1.ConfigMapList, *corev1ac.ConfigMapApplyConfiguration] {
+	return NewResource[*corev1.ConfigMap, *corev1.ConfigMapList, *corev1ac.ConfigMapApplyConfiguration](c.client.CoreV1().ConfigMaps(namespace))
+}
+
+// Secrets returns the Secret Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) Secrets(namespace string) *Resource[*corev1.Secret, *corev1.SecretList, *corev1ac.SecretApplyConfiguration] {
+	return NewResource[*corev1.Secret, *corev1.SecretList, *corev1ac.SecretApplyConfiguration](c.client.CoreV1().Secrets(namespace))
+}
+
+// ServiceAccounts returns the ServiceAccount Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) ServiceAccounts(namespace string) *Resource[*corev1.ServiceAccount, *corev1.ServiceAccountList, *corev1ac.ServiceAccountApplyConfiguration] {
+	return NewResource[*corev1.ServiceAccount, *corev1.ServiceAccountList, *corev1ac.ServiceAccountApplyConfiguration](c.client.CoreV1().ServiceAccounts(namespace))
+}
+
+// Endpoints returns the Endpoints Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) Endpoints(namespace string) *Resource[*corev1.Endpoints, *corev1.EndpointsList, *corev1ac.EndpointsApplyConfiguration] {
+	return NewResource[*corev1.Endpoints, *corev1.EndpointsList, *corev1ac.EndpointsApplyConfiguration](c.client.CoreV1().Endpoints(namespace))
+}
+
+// PersistentVolumeClaims returns the PersistentVolumeClaim Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) PersistentVolumeClaims(namespace string) *Resource[*corev1.PersistentVolumeClaim, *corev1.PersistentVolumeClaimList, *corev1ac.PersistentVolumeClaimApplyConfiguration] {
+	return NewResource[*corev1.PersistentVolumeClaim, *corev1.PersistentVolumeClaimList, *corev1ac.PersistentVolumeClaimApplyConfiguration](c.client.CoreV1().PersistentVolumeClaims(namespace))
+}
+
+// Events returns the Event Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) Events(namespace string) *Resource[*corev1.Event, *corev1.EventList, *corev1ac.EventApplyConfiguration] {
+	return NewResource[*corev1.Event, *corev1.EventList, *corev1ac.EventApplyConfiguration](c.client.CoreV1().Events(namespace))
+}
+
+// ResourceQuotas returns the ResourceQuota Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) ResourceQuotas(namespace string) *Resource[*corev1.ResourceQuota, *corev1.ResourceQuotaList, *corev1ac.ResourceQuotaApplyConfiguration] {
+	return NewResource[*corev1.ResourceQuota, *corev1.ResourceQuotaList, *corev1ac.ResourceQuotaApplyConfiguration](c.client.CoreV1().ResourceQuotas(namespace))
+}
+
+// LimitRanges returns the LimitRange Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) LimitRanges(namespace string) *Resource[*corev1.LimitRange, *corev1.LimitRangeList, *corev1ac.LimitRangeApplyConfiguration] {
+	return NewResource[*corev1.LimitRange, *corev1.LimitRangeList, *corev1ac.LimitRangeApplyConfiguration](c.client.CoreV1().LimitRanges(namespace))
+}
+
+// ReplicationControllers returns the ReplicationController Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) ReplicationControllers(namespace string) *Resource[*corev1.ReplicationController, *corev1.ReplicationControllerList, *corev1ac.ReplicationControllerApplyConfiguration] {
+	return NewResource[*corev1.ReplicationController, *corev1.ReplicationControllerList, *corev1ac.ReplicationControllerApplyConfiguration](c.client.CoreV1().ReplicationControllers(namespace))
+}
+
+// PodTemplates returns the PodTemplate Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) PodTemplates(namespace string) *Resource[*corev1.PodTemplate, *corev1.PodTemplateList, *corev1ac.PodTemplateApplyConfiguration] {
+	return NewResource[*corev1.PodTemplate, *corev1.PodTemplateList, *corev1ac.PodTemplateApplyConfiguration](c.client.CoreV1().PodTemplates(namespace))
+}
+
+// Namespaces returns the Namespace Resource.
+func (c *Client) Namespaces() *Resource[*corev1.Namespace, *corev1.NamespaceList, *corev1ac.NamespaceApplyConfiguration] {
+	return NewResource[*corev1.Namespace, *corev1.NamespaceList, *corev1ac.NamespaceApplyConfiguration](c.client.CoreV1().Namespaces())
+}
+
+// Nodes returns the Node Resource.
+func (c *Client) Nodes() *Resource[*corev1.Node, *corev1.NodeList, *corev1ac.NodeApplyConfiguration] {
+	return NewResource[*corev1.Node, *corev1.NodeList, *corev1ac.NodeApplyConfiguration](c.client.CoreV1().Nodes())
+}
+
+// PersistentVolumes returns the PersistentVolume Resource.
+func (c *Client) PersistentVolumes() *Resource[*corev1.PersistentVolume, *corev1.PersistentVolumeList, *corev1ac.PersistentVolumeApplyConfiguration] {
+	return NewResource[*corev1.PersistentVolume, *corev1.PersistentVolumeList, *corev1ac.PersistentVolumeApplyConfiguration](c.client.CoreV1().PersistentVolumes())
+}
+
+// Deployments returns the Deployment Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) Deployments(namespace string) *Resource[*appsv1.Deployment, *appsv1.DeploymentList, *appsv1ac.DeploymentApplyConfiguration] {
+	return NewResource[*appsv1.Deployment, *appsv1.DeploymentList, *appsv1ac.DeploymentApplyConfiguration](c.client.AppsV1().Deployments(namespace))
+}
+
+// DaemonSets returns the DaemonSet Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) DaemonSets(namespace string) *Resource[*appsv1.DaemonSet, *appsv1.DaemonSetList, *appsv1ac.DaemonSetApplyConfiguration] {
+	return NewResource[*appsv1.DaemonSet, *appsv1.DaemonSetList, *appsv1ac.DaemonSetApplyConfiguration](c.client.AppsV1().DaemonSets(namespace))
+}
+
+// StatefulSets returns the StatefulSet Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) StatefulSets(namespace string) *Resource[*appsv1.StatefulSet, *appsv1.StatefulSetList, *appsv1ac.StatefulSetApplyConfiguration] {
+	return NewResource[*appsv1.StatefulSet, *appsv1.StatefulSetList, *appsv1ac.StatefulSetApplyConfiguration](c.client.AppsV1().StatefulSets(namespace))
+}
+
+// ReplicaSets returns the ReplicaSet Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) ReplicaSets(namespace string) *Resource[*appsv1.ReplicaSet, *appsv1.ReplicaSetList, *appsv1ac.ReplicaSetApplyConfiguration] {
+	return NewResource[*appsv1.ReplicaSet, *appsv1.ReplicaSetList, *appsv1ac.ReplicaSetApplyConfiguration](c.client.AppsV1().ReplicaSets(namespace))
+}
+
+// ControllerRevisions returns the ControllerRevision Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) ControllerRevisions(namespace string) *Resource[*appsv1.ControllerRevision, *appsv1.ControllerRevisionList, *appsv1ac.ControllerRevisionApplyConfiguration] {
+	return NewResource[*appsv1.ControllerRevision, *appsv1.ControllerRevisionList, *appsv1ac.ControllerRevisionApplyConfiguration](c.client.AppsV1().ControllerRevisions(namespace))
+}
+
+// Jobs returns the Job Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) Jobs(namespace string) *Resource[*batchv1.Job, *batchv1.JobList, *batchv1ac.JobApplyConfiguration] {
+	return NewResource[*batchv1.Job, *batchv1.JobList, *batchv1ac.JobApplyConfiguration](c.client.BatchV1().Jobs(namespace))
+}
+
+// CronJobs returns the CronJob Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) CronJobs(namespace string) *Resource[*batchv1.CronJob, *batchv1.CronJobList, *batchv1ac.CronJobApplyConfiguration] {
+	return NewResource[*batchv1.CronJob, *batchv1.CronJobList, *batchv1ac.CronJobApplyConfiguration](c.client.BatchV1().CronJobs(namespace))
+}
+
+// HorizontalPodAutoscalers returns the HorizontalPodAutoscaler Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) HorizontalPodAutoscalers(namespace string) *Resource[*autoscalingv2.HorizontalPodAutoscaler, *autoscalingv2.HorizontalPodAutoscalerList, *autoscalingv2ac.HorizontalPodAutoscalerApplyConfiguration] {
+	return NewResource[*autoscalingv2.HorizontalPodAutoscaler, *autoscalingv2.HorizontalPodAutoscalerList, *autoscalingv2ac.HorizontalPodAutoscalerApplyConfiguration](c.client.AutoscalingV2().HorizontalPodAutoscalers(namespace))
+}
+
+// PodDisruptionBudgets returns the PodDisruptionBudget Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) PodDisruptionBudgets(namespace string) *Resource[*policyv1.PodDisruptionBudget, *policyv1.PodDisruptionBudgetList, *policyv1ac.PodDisruptionBudgetApplyConfiguration] {
+	return NewResource[*policyv1.PodDisruptionBudget, *policyv1.PodDisruptionBudgetList, *policyv1ac.PodDisruptionBudgetApplyConfiguration](c.client.PolicyV1().PodDisruptionBudgets(namespace))
+}
+
+// Ingresses returns the Ingress Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) Ingresses(namespace string) *Resource[*networkv1.Ingress, *networkv1.IngressList, *networkv1ac.IngressApplyConfiguration] {
+	return NewResource[*networkv1.Ingress, *networkv1.IngressList, *networkv1ac.IngressApplyConfiguration](c.client.NetworkingV1().Ingresses(namespace))
+}
+
+// NetworkPolicies returns the NetworkPolicy Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) NetworkPolicies(namespace string) *Resource[*networkv1.NetworkPolicy, *networkv1.NetworkPolicyList, *networkv1ac.NetworkPolicyApplyConfiguration] {
+	return NewResource[*networkv1.NetworkPolicy, *networkv1.NetworkPolicyList, *networkv1ac.NetworkPolicyApplyConfiguration](c.client.NetworkingV1().NetworkPolicies(namespace))
+}
+
+// IngressClasses returns the IngressClass Resource.
+func (c *Client) IngressClasses() *Resource[*networkv1.IngressClass, *networkv1.IngressClassList, *networkv1ac.IngressClassApplyConfiguration] {
+	return NewResource[*networkv1.IngressClass, *networkv1.IngressClassList, *networkv1ac.IngressClassApplyConfiguration](c.client.NetworkingV1().IngressClasses())
+}
+
+// IPAddresses returns the IPAddress Resource.
+func (c *Client) IPAddresses() *Resource[*networkv1.IPAddress, *networkv1.IPAddressList, *networkv1ac.IPAddressApplyConfiguration] {
+	return NewResource[*networkv1.IPAddress, *networkv1.IPAddressList, *networkv1ac.IPAddressApplyConfiguration](c.client.NetworkingV1().IPAddresses())
+}
+
+// ServiceCIDRs returns the ServiceCIDR Resource.
+func (c *Client) ServiceCIDRs() *Resource[*networkv1.ServiceCIDR, *networkv1.ServiceCIDRList, *networkv1ac.ServiceCIDRApplyConfiguration] {
+	return NewResource[*networkv1.ServiceCIDR, *networkv1.ServiceCIDRList, *networkv1ac.ServiceCIDRApplyConfiguration](c.client.NetworkingV1().ServiceCIDRs())
+}
+
+// EndpointSlices returns the EndpointSlice Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) EndpointSlices(namespace string) *Resource[*discoveryv1.EndpointSlice, *discoveryv1.EndpointSliceList, *discoveryv1ac.EndpointSliceApplyConfiguration] {
+	return NewResource[*discoveryv1.EndpointSlice, *discoveryv1.EndpointSliceList, *discoveryv1ac.EndpointSliceApplyConfiguration](c.client.DiscoveryV1().EndpointSlices(namespace))
+}
+
+// Roles returns the Role Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) Roles(namespace string) *Resource[*rbacv1.Role, *rbacv1.RoleList, *rbacv1ac.RoleApplyConfiguration] {
+	return NewResource[*rbacv1.Role, *rbacv1.RoleList, *rbacv1ac.RoleApplyConfiguration](c.client.RbacV1().Roles(namespace))
+}
+
+// RoleBindings returns the RoleBinding Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) RoleBindings(namespace string) *Resource[*rbacv1.RoleBinding, *rbacv1.RoleBindingList, *rbacv1ac.RoleBindingApplyConfiguration] {
+	return NewResource[*rbacv1.RoleBinding, *rbacv1.RoleBindingList, *rbacv1ac.RoleBindingApplyConfiguration](c.client.RbacV1().RoleBindings(namespace))
+}
+
+// ClusterRoles returns the ClusterRole Resource.
+func (c *Client) ClusterRoles() *Resource[*rbacv1.ClusterRole, *rbacv1.ClusterRoleList, *rbacv1ac.ClusterRoleApplyConfiguration] {
+	return NewResource[*rbacv1.ClusterRole, *rbacv1.ClusterRoleList, *rbacv1ac.ClusterRoleApplyConfiguration](c.client.RbacV1().ClusterRoles())
+}
+
+// ClusterRoleBindings returns the ClusterRoleBinding Resource.
+func (c *Client) ClusterRoleBindings() *Resource[*rbacv1.ClusterRoleBinding, *rbacv1.ClusterRoleBindingList, *rbacv1ac.ClusterRoleBindingApplyConfiguration] {
+	return NewResource[*rbacv1.ClusterRoleBinding, *rbacv1.ClusterRoleBindingList, *rbacv1ac.ClusterRoleBindingApplyConfiguration](c.client.RbacV1().ClusterRoleBindings())
+}
+
+// StorageClasses returns the StorageClass Resource.
+func (c *Client) StorageClasses() *Resource[*storagev1.StorageClass, *storagev1.StorageClassList, *storagev1ac.StorageClassApplyConfiguration] {
+	return NewResource[*storagev1.StorageClass, *storagev1.StorageClassList, *storagev1ac.StorageClassApplyConfiguration](c.client.StorageV1().StorageClasses())
+}
+
+// CSIStorageCapacities returns the CSIStorageCapacity Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) CSIStorageCapacities(namespace string) *Resource[*storagev1.CSIStorageCapacity, *storagev1.CSIStorageCapacityList, *storagev1ac.CSIStorageCapacityApplyConfiguration] {
+	return NewResource[*storagev1.CSIStorageCapacity, *storagev1.CSIStorageCapacityList, *storagev1ac.CSIStorageCapacityApplyConfiguration](c.client.StorageV1().CSIStorageCapacities(namespace))
+}
+
+// CSIDrivers returns the CSIDriver Resource.
+func (c *Client) CSIDrivers() *Resource[*storagev1.CSIDriver, *storagev1.CSIDriverList, *storagev1ac.CSIDriverApplyConfiguration] {
+	return NewResource[*storagev1.CSIDriver, *storagev1.CSIDriverList, *storagev1ac.CSIDriverApplyConfiguration](c.client.StorageV1().CSIDrivers())
+}
+
+// CSINodes returns the CSINode Resource.
+func (c *Client) CSINodes() *Resource[*storagev1.CSINode, *storagev1.CSINodeList, *storagev1ac.CSINodeApplyConfiguration] {
+	return NewResource[*storagev1.CSINode, *storagev1.CSINodeList, *storagev1ac.CSINodeApplyConfiguration](c.client.StorageV1().CSINodes())
+}
+
+// VolumeAttachments returns the VolumeAttachment Resource.
+func (c *Client) VolumeAttachments() *Resource[*storagev1.VolumeAttachment, *storagev1.VolumeAttachmentList, *storagev1ac.VolumeAttachmentApplyConfiguration] {
+	return NewResource[*storagev1.VolumeAttachment, *storagev1.VolumeAttachmentList, *storagev1ac.VolumeAttachmentApplyConfiguration](c.client.StorageV1().VolumeAttachments())
+}
+
+// VolumeAttributesClasses returns the VolumeAttributesClass Resource.
+func (c *Client) VolumeAttributesClasses() *Resource[*storagev1.VolumeAttributesClass, *storagev1.VolumeAttributesClassList, *storagev1ac.VolumeAttributesClassApplyConfiguration] {
+	return NewResource[*storagev1.VolumeAttributesClass, *storagev1.VolumeAttributesClassList, *storagev1ac.VolumeAttributesClassApplyConfiguration](c.client.StorageV1().VolumeAttributesClasses())
+}
+
+// Leases returns the Lease Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) Leases(namespace string) *Resource[*coordinationv1.Lease, *coordinationv1.LeaseList, *coordinationv1ac.LeaseApplyConfiguration] {
+	return NewResource[*coordinationv1.Lease, *coordinationv1.LeaseList, *coordinationv1ac.LeaseApplyConfiguration](c.client.CoordinationV1().Leases(namespace))
+}
+
+// PriorityClasses returns the PriorityClass Resource.
+func (c *Client) PriorityClasses() *Resource[*schedulingv1.PriorityClass, *schedulingv1.PriorityClassList, *schedulingv1ac.PriorityClassApplyConfiguration] {
+	return NewResource[*schedulingv1.PriorityClass, *schedulingv1.PriorityClassList, *schedulingv1ac.PriorityClassApplyConfiguration](c.client.SchedulingV1().PriorityClasses())
+}
+
+// RuntimeClasses returns the RuntimeClass Resource.
+func (c *Client) RuntimeClasses() *Resource[*nodev1.RuntimeClass, *nodev1.RuntimeClassList, *nodev1ac.RuntimeClassApplyConfiguration] {
+	return NewResource[*nodev1.RuntimeClass, *nodev1.RuntimeClassList, *nodev1ac.RuntimeClassApplyConfiguration](c.client.NodeV1().RuntimeClasses())
+}
+
+// CertificateSigningRequests returns the CertificateSigningRequest Resource.
+func (c *Client) CertificateSigningRequests() *Resource[*certificatesv1.CertificateSigningRequest, *certificatesv1.CertificateSigningRequestList, *certificatesv1ac.CertificateSigningRequestApplyConfiguration] {
+	return NewResource[*certificatesv1.CertificateSigningRequest, *certificatesv1.CertificateSigningRequestList, *certificatesv1ac.CertificateSigningRequestApplyConfiguration](c.client.CertificatesV1().CertificateSigningRequests())
+}
+
+// ValidatingWebhookConfigurations returns the ValidatingWebhookConfiguration Resource.
+func (c *Client) ValidatingWebhookConfigurations() *Resource[*admissionregistrationv1.ValidatingWebhookConfiguration, *admissionregistrationv1.ValidatingWebhookConfigurationList, *admissionregistrationv1ac.ValidatingWebhookConfigurationApplyConfiguration] {
+	return NewResource[*admissionregistrationv1.ValidatingWebhookConfiguration, *admissionregistrationv1.ValidatingWebhookConfigurationList, *admissionregistrationv1ac.ValidatingWebhookConfigurationApplyConfiguration](c.client.AdmissionregistrationV1().ValidatingWebhookConfigurations())
+}
+
+// MutatingWebhookConfigurations returns the MutatingWebhookConfiguration Resource.
+func (c *Client) MutatingWebhookConfigurations() *Resource[*admissionregistrationv1.MutatingWebhookConfiguration, *admissionregistrationv1.MutatingWebhookConfigurationList, *admissionregistrationv1ac.MutatingWebhookConfigurationApplyConfiguration] {
+	return NewResource[*admissionregistrationv1.MutatingWebhookConfiguration, *admissionregistrationv1.MutatingWebhookConfigurationList, *admissionregistrationv1ac.MutatingWebhookConfigurationApplyConfiguration](c.client.AdmissionregistrationV1().MutatingWebhookConfigurations())
+}
+
+// ValidatingAdmissionPolicies returns the ValidatingAdmissionPolicy Resource.
+func (c *Client) ValidatingAdmissionPolicies() *Resource[*admissionregistrationv1.ValidatingAdmissionPolicy, *admissionregistrationv1.ValidatingAdmissionPolicyList, *admissionregistrationv1ac.ValidatingAdmissionPolicyApplyConfiguration] {
+	return NewResource[*admissionregistrationv1.ValidatingAdmissionPolicy, *admissionregistrationv1.ValidatingAdmissionPolicyList, *admissionregistrationv1ac.ValidatingAdmissionPolicyApplyConfiguration](c.client.AdmissionregistrationV1().ValidatingAdmissionPolicies())
+}
+
+// ValidatingAdmissionPolicyBindings returns the ValidatingAdmissionPolicyBinding Resource.
+func (c *Client) ValidatingAdmissionPolicyBindings() *Resource[*admissionregistrationv1.ValidatingAdmissionPolicyBinding, *admissionregistrationv1.ValidatingAdmissionPolicyBindingList, *admissionregistrationv1ac.ValidatingAdmissionPolicyBindingApplyConfiguration] {
+	return NewResource[*admissionregistrationv1.ValidatingAdmissionPolicyBinding, *admissionregistrationv1.ValidatingAdmissionPolicyBindingList, *admissionregistrationv1ac.ValidatingAdmissionPolicyBindingApplyConfiguration](c.client.AdmissionregistrationV1().ValidatingAdmissionPolicyBindings())
+}
+
+// MutatingAdmissionPolicies returns the MutatingAdmissionPolicy Resource.
+func (c *Client) MutatingAdmissionPolicies() *Resource[*admissionregistrationv1.MutatingAdmissionPolicy, *admissionregistrationv1.MutatingAdmissionPolicyList, *admissionregistrationv1ac.MutatingAdmissionPolicyApplyConfiguration] {
+	return NewResource[*admissionregistrationv1.MutatingAdmissionPolicy, *admissionregistrationv1.MutatingAdmissionPolicyList, *admissionregistrationv1ac.MutatingAdmissionPolicyApplyConfiguration](c.client.AdmissionregistrationV1().MutatingAdmissionPolicies())
+}
+
+// MutatingAdmissionPolicyBindings returns the MutatingAdmissionPolicyBinding Resource.
+func (c *Client) MutatingAdmissionPolicyBindings() *Resource[*admissionregistrationv1.MutatingAdmissionPolicyBinding, *admissionregistrationv1.MutatingAdmissionPolicyBindingList, *admissionregistrationv1ac.MutatingAdmissionPolicyBindingApplyConfiguration] {
+	return NewResource[*admissionregistrationv1.MutatingAdmissionPolicyBinding, *admissionregistrationv1.MutatingAdmissionPolicyBindingList, *admissionregistrationv1ac.MutatingAdmissionPolicyBindingApplyConfiguration](c.client.AdmissionregistrationV1().MutatingAdmissionPolicyBindings())
+}
+
+// FlowSchemas returns the FlowSchema Resource.
+func (c *Client) FlowSchemas() *Resource[*flowcontrolv1.FlowSchema, *flowcontrolv1.FlowSchemaList, *flowcontrolv1ac.FlowSchemaApplyConfiguration] {
+	return NewResource[*flowcontrolv1.FlowSchema, *flowcontrolv1.FlowSchemaList, *flowcontrolv1ac.FlowSchemaApplyConfiguration](c.client.FlowcontrolV1().FlowSchemas())
+}
+
+// PriorityLevelConfigurations returns the PriorityLevelConfiguration Resource.
+func (c *Client) PriorityLevelConfigurations() *Resource[*flowcontrolv1.PriorityLevelConfiguration, *flowcontrolv1.PriorityLevelConfigurationList, *flowcontrolv1ac.PriorityLevelConfigurationApplyConfiguration] {
+	return NewResource[*flowcontrolv1.PriorityLevelConfiguration, *flowcontrolv1.PriorityLevelConfigurationList, *flowcontrolv1ac.PriorityLevelConfigurationApplyConfiguration](c.client.FlowcontrolV1().PriorityLevelConfigurations())
+}
+
+// DeviceClasses returns the DeviceClass Resource.
+func (c *Client) DeviceClasses() *Resource[*resourcev1.DeviceClass, *resourcev1.DeviceClassList, *resourcev1ac.DeviceClassApplyConfiguration] {
+	return NewResource[*resourcev1.DeviceClass, *resourcev1.DeviceClassList, *resourcev1ac.DeviceClassApplyConfiguration](c.client.ResourceV1().DeviceClasses())
+}
+
+// ResourceSlices returns the ResourceSlice Resource.
+func (c *Client) ResourceSlices() *Resource[*resourcev1.ResourceSlice, *resourcev1.ResourceSliceList, *resourcev1ac.ResourceSliceApplyConfiguration] {
+	return NewResource[*resourcev1.ResourceSlice, *resourcev1.ResourceSliceList, *resourcev1ac.ResourceSliceApplyConfiguration](c.client.ResourceV1().ResourceSlices())
+}
+
+// ResourceClaims returns the ResourceClaim Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) ResourceClaims(namespace string) *Resource[*resourcev1.ResourceClaim, *resourcev1.ResourceClaimList, *resourcev1ac.ResourceClaimApplyConfiguration] {
+	return NewResource[*resourcev1.ResourceClaim, *resourcev1.ResourceClaimList, *resourcev1ac.ResourceClaimApplyConfiguration](c.client.ResourceV1().ResourceClaims(namespace))
+}
+
+// ResourceClaimTemplates returns the ResourceClaimTemplate Resource in the given namespace, an empty namespace means all namespaces.
+func (c *Client) ResourceClaimTemplates(namespace string) *Resource[*resourcev1.ResourceClaimTemplate, *resourcev1.ResourceClaimTemplateList, *resourcev1ac.ResourceClaimTemplateApplyConfiguration] {
+	return NewResource[*resourcev1.ResourceClaimTemplate, *resourcev1.ResourceClaimTemplateList, *resourcev1ac.ResourceClaimTemplateApplyConfiguration](c.client.ResourceV1().ResourceClaimTemplates(namespace))
+}
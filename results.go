@@ -0,0 +1,123 @@
+package kube
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Action is the action taken on an object by Apply or Delete.
+type Action string
+
+const (
+	ActionCreated    Action = "created"
+	ActionConfigured Action = "configured"
+	ActionUnchanged  Action = "unchanged"
+	ActionDeleted    Action = "deleted"
+	ActionPruned     Action = "pruned"
+	ActionSkipped    Action = "skipped"
+	ActionFailed     Action = "failed"
+)
+
+// Result is the result of applying or deleting an object.
+type Result struct {
+	GroupVersionKind schema.GroupVersionKind
+	Namespace        string
+	Name             string
+	Action           Action
+	// Err is the error of the object if Action is ActionFailed.
+	Err error
+}
+
+func newResult(obj runtime.Object) Result {
+	namespace, name, _ := retrievesMetaFromObject(obj)
+	return Result{
+		GroupVersionKind: obj.GetObjectKind().GroupVersionKind(),
+		Namespace:        namespace,
+		Name:             name,
+	}
+}
+
+func (r Result) failed(err error) Result {
+	r.Action = ActionFailed
+	r.Err = err
+	return r
+}
+
+// String returns the result like the output of kubectl, e.g. "deployment.apps/nginx configured".
+func (r Result) String() string {
+	kind := strings.ToLower(r.GroupVersionKind.Kind)
+	if r.GroupVersionKind.Group != "" {
+		kind = kind + "." + r.GroupVersionKind.Group
+	}
+	s := fmt.Sprintf("%s/%s %s", kind, r.Name, r.Action)
+	if r.Err != nil {
+		s = fmt.Sprintf("%s: %s", s, r.Err)
+	}
+	return s
+}
+
+// Results is a list of Result.
+type Results []Result
+
+// Failed returns the results of failed objects.
+func (rs Results) Failed() Results {
+	return rs.filter(ActionFailed)
+}
+
+// Skipped returns the results of objects skipped after a failure.
+func (rs Results) Skipped() Results {
+	return rs.filter(ActionSkipped)
+}
+
+// Err returns the aggregated errors of failed objects, nil if there is no failure.
+func (rs Results) Err() error {
+	var errs []error
+	for _, r := range rs.Failed() {
+		errs = append(errs, fmt.Errorf("%s %s: %w", r.GroupVersionKind.Kind, objectKey(r.Namespace, r.Name), r.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// String returns the summary of results, one result per line.
+func (rs Results) String() string {
+	lines := make([]string, 0, len(rs))
+	for _, r := range rs {
+		lines = append(lines, r.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (rs Results) filter(action Action) Results {
+	var filtered Results
+	for _, r := range rs {
+		if r.Action == action {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// appliedAction returns the action taken on the live object, nil if not found,
+// by comparing it with the object returned by the server.
+func appliedAction(live, applied *unstructured.Unstructured) (Action, error) {
+	if live == nil {
+		return ActionCreated, nil
+	}
+	from, err := diffableYAML(live)
+	if err != nil {
+		return "", err
+	}
+	to, err := diffableYAML(applied)
+	if err != nil {
+		return "", err
+	}
+	if from == to {
+		return ActionUnchanged, nil
+	}
+	return ActionConfigured, nil
+}
@@ -0,0 +1,62 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestResults(t *testing.T) {
+	deploy := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	cm := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	results := Results{
+		{GroupVersionKind: cm, Namespace: "default", Name: "cm1", Action: ActionCreated},
+		{GroupVersionKind: deploy, Namespace: "default", Name: "nginx", Action: ActionFailed, Err: errors.New("forbidden")},
+		{GroupVersionKind: cm, Namespace: "default", Name: "cm2", Action: ActionSkipped},
+	}
+
+	assert.Len(t, results.Failed(), 1)
+	assert.Len(t, results.Skipped(), 1)
+	assert.EqualError(t, results.Err(), "Deployment default/nginx: forbidden")
+	assert.Equal(t, "configmap/cm1 created\ndeployment.apps/nginx failed: forbidden\nconfigmap/cm2 skipped", results.String())
+	assert.NoError(t, results[:1].Err())
+}
+
+func TestClientApplyWithResults(t *testing.T) {
+	files := []string{"testdata/content-partial.yaml"}
+
+	t.Run("apply:stop-on-error", func(t *testing.T) {
+		results, err := mockcli.ApplyWithResults(context.TODO(), files, WithFileOrder())
+		require.Error(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, ActionFailed, results[0].Action)
+		assert.Equal(t, ActionSkipped, results[1].Action)
+	})
+
+	t.Run("apply:continue-on-error", func(t *testing.T) {
+		results, err := mockcli.ApplyWithResults(context.TODO(), files, WithFileOrder(), WithContinueOnError())
+		require.Error(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, ActionFailed, results[0].Action)
+		assert.Equal(t, ActionCreated, results[1].Action)
+		assert.Len(t, results.Failed(), 1)
+	})
+
+	t.Run("apply:unchanged", func(t *testing.T) {
+		results, err := mockcli.ApplyWithResults(context.TODO(), files, WithFileOrder(), WithContinueOnError())
+		require.Error(t, err)
+		assert.Equal(t, ActionUnchanged, results[1].Action)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		results, err := mockcli.DeleteWithResults(context.TODO(), files)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, ActionUnchanged, results[0].Action)
+		assert.Equal(t, ActionDeleted, results[1].Action)
+	})
+}
@@ -4,18 +4,22 @@ import (
 	"context"
 
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
 // GetSecrets returns a SecretList.
 func (c *Client) GetSecrets(ctx context.Context, namespace string, label ...string) (*corev1.SecretList, error) {
-	return c.client.CoreV1().Secrets(namespace).List(ctx, listOptions(label))
+	return c.Secrets(namespace).List(ctx, labelListOptions(label))
+}
+
+// GetSecretsWithOptions is like GetSecrets, but with the given ListOption.
+func (c *Client) GetSecretsWithOptions(ctx context.Context, namespace string, opts ...ListOption) (*corev1.SecretList, error) {
+	return listWithOptions(ctx, c.Secrets(namespace).List, opts)
 }
 
 // GetSecret returns a Secret with the given name.
 func (c *Client) GetSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
-	return c.client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	return c.Secrets(namespace).Get(ctx, name)
 }
 
 // CreateSecret creates a new Secret.
@@ -23,7 +27,7 @@ func (c *Client) CreateSecret(ctx context.Context, secret *corev1.Secret) (*core
 	if len(secret.Namespace) == 0 {
 		return nil, ErrorMissingNamespace
 	}
-	return c.client.CoreV1().Secrets(secret.Namespace).Create(ctx, secret, metav1.CreateOptions{})
+	return c.Secrets(secret.Namespace).Create(ctx, secret)
 }
 
 // UpdateSecret updates the given Secret.
@@ -31,18 +35,12 @@ func (c *Client) UpdateSecret(ctx context.Context, secret *corev1.Secret) (*core
 	if len(secret.Namespace) == 0 {
 		return nil, ErrorMissingNamespace
 	}
-	return c.client.CoreV1().Secrets(secret.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	return c.Secrets(secret.Namespace).Update(ctx, secret)
 }
 
 // PatchSecret patch the given Secret.
 func (c *Client) PatchSecret(ctx context.Context, namespace, name string, data []byte) (*corev1.Secret, error) {
-	return c.client.CoreV1().Secrets(namespace).Patch(
-		ctx,
-		name,
-		types.StrategicMergePatchType,
-		data,
-		metav1.PatchOptions{},
-	)
+	return c.Secrets(namespace).Patch(ctx, name, types.StrategicMergePatchType, data)
 }
 
 // ApplySecret updates a Secret, and creates a new Secret if not exist.
@@ -74,6 +72,6 @@ func (c *Client) ApplySecretBytes(ctx context.Context, namespace, name string, d
 }
 
 // DeleteSecret deletes a Secret.
-func (c *Client) DeleteSecret(ctx context.Context, namespace, name string) error {
-	return c.client.CoreV1().Secrets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+func (c *Client) DeleteSecret(ctx context.Context, namespace, name string, opts ...DeleteOption) error {
+	return c.Secrets(namespace).Delete(ctx, name, opts...)
 }
@@ -0,0 +1,211 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// ListOption configures the ListOptions of GetXsWithOptions helpers, e.g. Client.GetPodsWithOptions.
+// Selectors given by multiple options are ANDed.
+type ListOption func(*listOptions)
+
+type listOptions struct {
+	metav1.ListOptions
+	labelSelectors []string
+	fieldSelectors []string
+	errs           []error
+}
+
+// WithLabelSelector selects objects by the given label selector, e.g. "app=nginx,tier in (web)".
+func WithLabelSelector(selector string) ListOption {
+	return func(o *listOptions) {
+		if selector != "" {
+			o.labelSelectors = append(o.labelSelectors, selector)
+		}
+	}
+}
+
+// WithFieldSelector selects objects by the given field selector, e.g. "spec.nodeName=node1".
+func WithFieldSelector(selector string) ListOption {
+	return func(o *listOptions) {
+		if selector != "" {
+			o.fieldSelectors = append(o.fieldSelectors, selector)
+		}
+	}
+}
+
+// WithLabels selects objects by the given LabelSelector.
+func WithLabels(selector *LabelSelector) ListOption {
+	return func(o *listOptions) {
+		if selector.err != nil {
+			o.errs = append(o.errs, selector.err)
+			return
+		}
+		WithLabelSelector(selector.String())(o)
+	}
+}
+
+// WithFields selects objects by the given FieldSelector.
+func WithFields(selector *FieldSelector) ListOption {
+	return WithFieldSelector(selector.String())
+}
+
+// WithResourceVersion sets the resourceVersion of the list, see metav1.ListOptions.ResourceVersion.
+// e.g. "0" lists objects from the cache of the API server.
+func WithResourceVersion(resourceVersion string) ListOption {
+	return func(o *listOptions) {
+		o.ResourceVersion = resourceVersion
+	}
+}
+
+// WithListTimeout sets the server-side timeout of the list. The timeout is rounded up to
+// whole seconds, as a timeout of 0 seconds means no timeout.
+func WithListTimeout(timeout time.Duration) ListOption {
+	return func(o *listOptions) {
+		if timeout <= 0 {
+			return
+		}
+		seconds := int64(math.Ceil(timeout.Seconds()))
+		o.TimeoutSeconds = &seconds
+	}
+}
+
+// WithLimit limits the number of objects of the list, the list is paginated by
+// DefaultPageSize if no limit is given.
+func WithLimit(limit int64) ListOption {
+	return func(o *listOptions) {
+		o.Limit = limit
+	}
+}
+
+// NewListOptions returns the metav1.ListOptions with the given ListOption, it can be used with
+// Resource.List or Resource.Items. An error is returned if a selector is invalid.
+func NewListOptions(opts ...ListOption) (metav1.ListOptions, error) {
+	o := &listOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if err := errors.Join(o.errs...); err != nil {
+		return metav1.ListOptions{}, err
+	}
+	o.LabelSelector = strings.Join(o.labelSelectors, ",")
+	o.FieldSelector = strings.Join(o.fieldSelectors, ",")
+	return o.ListOptions, nil
+}
+
+// listWithOptions lists objects with the given list function and ListOption, paginated like listAll.
+func listWithOptions[L runtime.Object](ctx context.Context, list func(context.Context, metav1.ListOptions) (L, error), opts []ListOption) (L, error) {
+	lo, err := NewListOptions(opts...)
+	if err != nil {
+		var zero L
+		return zero, err
+	}
+	return listAll(ctx, list, lo)
+}
+
+// LabelSelector builds a label selector, requirements are ANDed:
+//
+//	kube.NewLabelSelector().Equals("app", "nginx").In("tier", "web", "api").Exists("release")
+//
+// Invalid keys or values are reported by WithLabels.
+type LabelSelector struct {
+	selector labels.Selector
+	err      error
+}
+
+// NewLabelSelector returns an empty LabelSelector, it selects all objects.
+func NewLabelSelector() *LabelSelector {
+	return &LabelSelector{selector: labels.Everything()}
+}
+
+// Equals requires the label key to be equal to value.
+func (s *LabelSelector) Equals(key, value string) *LabelSelector {
+	return s.add(key, selection.Equals, value)
+}
+
+// NotEquals requires the label key to be absent, or not equal to value.
+func (s *LabelSelector) NotEquals(key, value string) *LabelSelector {
+	return s.add(key, selection.NotEquals, value)
+}
+
+// In requires the label key to be one of the given values.
+func (s *LabelSelector) In(key string, values ...string) *LabelSelector {
+	return s.add(key, selection.In, values...)
+}
+
+// NotIn requires the label key to be absent, or not one of the given values.
+func (s *LabelSelector) NotIn(key string, values ...string) *LabelSelector {
+	return s.add(key, selection.NotIn, values...)
+}
+
+// Exists requires the label key to be present.
+func (s *LabelSelector) Exists(key string) *LabelSelector {
+	return s.add(key, selection.Exists)
+}
+
+// DoesNotExist requires the label key to be absent.
+func (s *LabelSelector) DoesNotExist(key string) *LabelSelector {
+	return s.add(key, selection.DoesNotExist)
+}
+
+// String returns the label selector, e.g. "app=nginx,tier in (api,web)".
+func (s *LabelSelector) String() string {
+	return s.selector.String()
+}
+
+// Err returns the first error of invalid keys or values.
+func (s *LabelSelector) Err() error {
+	return s.err
+}
+
+func (s *LabelSelector) add(key string, op selection.Operator, values ...string) *LabelSelector {
+	r, err := labels.NewRequirement(key, op, values)
+	if err != nil {
+		if s.err == nil {
+			s.err = err
+		}
+		return s
+	}
+	s.selector = s.selector.Add(*r)
+	return s
+}
+
+// FieldSelector builds a field selector, requirements are ANDed:
+//
+//	kube.NewFieldSelector().Equals("spec.nodeName", "node1").NotEquals("status.phase", "Succeeded")
+//
+// The supported fields depend on the kind, e.g. metadata.name and metadata.namespace for all kinds.
+type FieldSelector struct {
+	selectors []fields.Selector
+}
+
+// NewFieldSelector returns an empty FieldSelector, it selects all objects.
+func NewFieldSelector() *FieldSelector {
+	return &FieldSelector{}
+}
+
+// Equals requires the field to be equal to value.
+func (s *FieldSelector) Equals(field, value string) *FieldSelector {
+	s.selectors = append(s.selectors, fields.OneTermEqualSelector(field, value))
+	return s
+}
+
+// NotEquals requires the field not to be equal to value.
+func (s *FieldSelector) NotEquals(field, value string) *FieldSelector {
+	s.selectors = append(s.selectors, fields.OneTermNotEqualSelector(field, value))
+	return s
+}
+
+// String returns the field selector, e.g. "spec.nodeName=node1,status.phase!=Succeeded".
+func (s *FieldSelector) String() string {
+	return fields.AndSelectors(s.selectors...).String()
+}
@@ -0,0 +1,75 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewListOptions(t *testing.T) {
+	opts, err := NewListOptions()
+	require.NoError(t, err)
+	assert.Equal(t, metav1.ListOptions{}, opts)
+
+	opts, err = NewListOptions(
+		WithLabelSelector("app=nginx"),
+		WithLabels(NewLabelSelector().In("tier", "web", "api").Exists("release").NotEquals("canary", "true")),
+		WithFields(NewFieldSelector().Equals("spec.nodeName", "node1")),
+		WithFieldSelector("status.phase!=Succeeded"),
+		WithResourceVersion("0"),
+		WithListTimeout(30*time.Second),
+		WithLimit(100),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "app=nginx,canary!=true,release,tier in (api,web)", opts.LabelSelector)
+	assert.Equal(t, "spec.nodeName=node1,status.phase!=Succeeded", opts.FieldSelector)
+	assert.Equal(t, "0", opts.ResourceVersion)
+	assert.Equal(t, int64(30), *opts.TimeoutSeconds)
+	assert.Equal(t, int64(100), opts.Limit)
+
+	for timeout, expected := range map[time.Duration]int64{
+		500 * time.Millisecond:  1,
+		time.Second:             1,
+		1500 * time.Millisecond: 2,
+	} {
+		opts, err = NewListOptions(WithListTimeout(timeout))
+		require.NoError(t, err)
+		assert.Equal(t, expected, *opts.TimeoutSeconds, timeout)
+	}
+	opts, err = NewListOptions(WithListTimeout(0))
+	require.NoError(t, err)
+	assert.Nil(t, opts.TimeoutSeconds)
+
+	sel := NewLabelSelector().Equals("app", "nginx").Equals("invalid key", "x")
+	assert.Error(t, sel.Err())
+	_, err = NewListOptions(WithLabels(sel))
+	assert.ErrorContains(t, err, "invalid key")
+}
+
+func TestFieldSelector(t *testing.T) {
+	assert.Equal(t, "", NewFieldSelector().String())
+	assert.Equal(t, "metadata.name=pod1,status.phase!=Running",
+		NewFieldSelector().Equals("metadata.name", "pod1").NotEquals("status.phase", "Running").String())
+}
+
+func TestGetPodsWithOptions(t *testing.T) {
+	cli := &Client{client: fake.NewClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", Labels: map[string]string{"app": "nginx", "tier": "web"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod2", Namespace: "default", Labels: map[string]string{"app": "nginx", "tier": "db"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod3", Namespace: "default"}},
+	)}
+
+	pods, err := cli.GetPodsWithOptions(context.TODO(), "default", WithLabels(NewLabelSelector().Equals("app", "nginx").NotIn("tier", "db")))
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 1)
+	assert.Equal(t, "pod1", pods.Items[0].Name)
+
+	_, err = cli.GetPodsWithOptions(context.TODO(), "default", WithLabels(NewLabelSelector().In("", "x")))
+	assert.Error(t, err)
+}
@@ -0,0 +1,140 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const conflictMessagePrefix = "conflict with "
+
+// FieldConflict is a field owned by another field manager.
+type FieldConflict struct {
+	// Field is the path of the conflicting field, e.g. ".spec.replicas".
+	Field string
+	// Manager is the field manager that owns the field.
+	Manager string
+	// Message is the raw conflict message returned by the server.
+	Message string
+}
+
+// ConflictError is returned when a server-side apply conflicts with fields
+// owned by other field managers. Use WithForceConflicts to take ownership of them.
+type ConflictError struct {
+	GroupVersionKind schema.GroupVersionKind
+	Namespace        string
+	Name             string
+	Conflicts        []FieldConflict
+
+	err error
+}
+
+func (e *ConflictError) Error() string {
+	msgs := make([]string, 0, len(e.Conflicts))
+	for _, c := range e.Conflicts {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", c.Message, c.Field))
+	}
+	return fmt.Sprintf("apply %s %s failed with %d conflict(s): %s",
+		e.GroupVersionKind.Kind, objectKey(e.Namespace, e.Name), len(e.Conflicts), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the underlying API error.
+func (e *ConflictError) Unwrap() error {
+	return e.err
+}
+
+// IsConflictError reports whether any error in err's chain is a ConflictError.
+func IsConflictError(err error) bool {
+	var ce *ConflictError
+	return errors.As(err, &ce)
+}
+
+func serverSideApplyObject(ctx context.Context, ri dynamic.ResourceInterface, obj *unstructured.Unstructured, opts *applyOptions) (*unstructured.Unstructured, error) {
+	applied, err := ri.Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{
+		FieldManager: opts.fieldManager,
+		Force:        opts.forceConflicts,
+		DryRun:       opts.dryRunAll(),
+	})
+	if err != nil {
+		return nil, newConflictError(obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName(), err)
+	}
+	return applied, nil
+}
+
+// manifestObject returns an unstructured copy of the given object. Fields that are
+// never part of a manifest (status, creationTimestamp) are dropped, so that they
+// are neither claimed by a field manager nor recorded as applied configuration.
+func manifestObject(obj runtime.Object) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	content = runtime.DeepCopyJSON(content)
+	unstructured.RemoveNestedField(content, "status")
+	unstructured.RemoveNestedField(content, "metadata", "creationTimestamp")
+	return &unstructured.Unstructured{Object: content}, nil
+}
+
+// newConflictError converts a field manager conflict returned by the server to a ConflictError,
+// any other error is returned as it is.
+func newConflictError(gvk schema.GroupVersionKind, namespace, name string, err error) error {
+	if !apierrors.IsConflict(err) {
+		return err
+	}
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) || status.Status().Details == nil {
+		return err
+	}
+	var conflicts []FieldConflict
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		conflicts = append(conflicts, FieldConflict{
+			Field:   cause.Field,
+			Manager: parseConflictManager(cause.Message),
+			Message: cause.Message,
+		})
+	}
+	if len(conflicts) == 0 {
+		return err
+	}
+	return &ConflictError{
+		GroupVersionKind: gvk,
+		Namespace:        namespace,
+		Name:             name,
+		Conflicts:        conflicts,
+		err:              err,
+	}
+}
+
+// parseConflictManager extracts the manager name from a conflict message,
+// e.g. `conflict with "kube-controller-manager" using apps/v1`.
+func parseConflictManager(msg string) string {
+	msg = strings.TrimPrefix(msg, conflictMessagePrefix)
+	if quoted, err := strconv.QuotedPrefix(msg); err == nil {
+		if manager, err := strconv.Unquote(quoted); err == nil {
+			return manager
+		}
+	}
+	if i := strings.Index(msg, " "); i > 0 {
+		return msg[:i]
+	}
+	return msg
+}
+
+func objectKey(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
@@ -0,0 +1,95 @@
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestNewConflictError(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+	t.Run("field manager conflicts", func(t *testing.T) {
+		serr := apierrors.NewApplyConflict([]metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldManagerConflict,
+				Message: `conflict with "kube-controller-manager" using apps/v1`,
+				Field:   ".spec.replicas",
+			},
+			{
+				Type:    metav1.CauseTypeFieldManagerConflict,
+				Message: `conflict with "istio-injector"`,
+				Field:   ".spec.template.spec.containers[name=\"istio-proxy\"]",
+			},
+		}, "Apply failed with 2 conflicts")
+
+		err := newConflictError(gvk, "default", "nginx", serr)
+		var ce *ConflictError
+		require.True(t, errors.As(err, &ce))
+		assert.True(t, IsConflictError(err))
+		assert.True(t, apierrors.IsConflict(err))
+		require.Len(t, ce.Conflicts, 2)
+		assert.Equal(t, ".spec.replicas", ce.Conflicts[0].Field)
+		assert.Equal(t, "kube-controller-manager", ce.Conflicts[0].Manager)
+		assert.Equal(t, "istio-injector", ce.Conflicts[1].Manager)
+		assert.Contains(t, err.Error(), "default/nginx")
+		assert.Contains(t, err.Error(), "2 conflict(s)")
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		nferr := apierrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, "nginx")
+		err := newConflictError(gvk, "default", "nginx", nferr)
+		assert.False(t, IsConflictError(err))
+		assert.Equal(t, nferr, err)
+	})
+}
+
+func TestServerSideApplyBody(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Service"), meta.RESTScopeNamespace)
+	client := dynamicfake.NewSimpleDynamicClient(scheme.Scheme)
+	var body map[string]interface{}
+	client.PrependReactor("patch", "services", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		require.Equal(t, types.ApplyPatchType, patch.GetPatchType())
+		require.NoError(t, json.Unmarshal(patch.GetPatch(), &body))
+		return true, &unstructured.Unstructured{Object: body}, nil
+	})
+	cli := &Client{dynamic: client, mapper: staticRESTMapper{mapper}}
+
+	content := []byte(`apiVersion: v1
+kind: Service
+metadata:
+  name: kube-svc
+  namespace: default
+spec:
+  ports:
+    - port: 80
+`)
+	_, err := cli.ApplyBytes(context.TODO(), content, WithServerSideApply("kube-test"))
+	require.NoError(t, err)
+	// only the fields of the manifest are claimed, e.g. targetPort is left to the server
+	assert.Equal(t, map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata":   map[string]interface{}{"name": "kube-svc", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"ports": []interface{}{map[string]interface{}{"port": float64(80)}},
+		},
+	}, body)
+}
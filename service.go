@@ -4,17 +4,21 @@ import (
 	"context"
 
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // GetServices returns a ServiceList.
 func (c *Client) GetServices(ctx context.Context, namespace string, label ...string) (*corev1.ServiceList, error) {
-	return c.client.CoreV1().Services(namespace).List(ctx, listOptions(label))
+	return c.Services(namespace).List(ctx, labelListOptions(label))
+}
+
+// GetServicesWithOptions is like GetServices, but with the given ListOption.
+func (c *Client) GetServicesWithOptions(ctx context.Context, namespace string, opts ...ListOption) (*corev1.ServiceList, error) {
+	return listWithOptions(ctx, c.Services(namespace).List, opts)
 }
 
 // GetService returns a Service with the given name.
 func (c *Client) GetService(ctx context.Context, namespace, name string) (*corev1.Service, error) {
-	return c.client.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	return c.Services(namespace).Get(ctx, name)
 }
 
 // CreateService creates a new Service.
@@ -22,10 +26,10 @@ func (c *Client) CreateService(ctx context.Context, svc *corev1.Service) (*corev
 	if len(svc.Namespace) == 0 {
 		return nil, ErrorMissingNamespace
 	}
-	return c.client.CoreV1().Services(svc.Namespace).Create(ctx, svc, metav1.CreateOptions{})
+	return c.Services(svc.Namespace).Create(ctx, svc)
 }
 
 // DeleteService deletes a Service.
-func (c *Client) DeleteService(ctx context.Context, namespace, name string) error {
-	return c.client.CoreV1().Services(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+func (c *Client) DeleteService(ctx context.Context, namespace, name string, opts ...DeleteOption) error {
+	return c.Services(namespace).Delete(ctx, name, opts...)
 }
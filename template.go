@@ -0,0 +1,79 @@
+package kube
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"reflect"
+	"regexp"
+	"text/template"
+)
+
+// envVarPattern matches the ${VAR} references expanded WithEnvSubst.
+var envVarPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// WithTemplate renders files through text/template before decoding them, with the given
+// values as the data. Besides the builtin functions of text/template, templates can use:
+//   - env: returns the value of an environment variable, e.g. {{ env "IMAGE_TAG" }}.
+//   - default: returns the given default if the value is empty, e.g. {{ index . "tag" | default "latest" }}.
+//   - b64enc: returns the base64 encoding of a string, e.g. {{ .password | b64enc }}.
+//
+// A value missing from the given values is an error, instead of rendering "<no value>".
+// Optional values are looked up with index, which returns nil for a missing key, e.g.
+// {{ index . "tag" | default "latest" }}.
+// Errors are reported with the file name and the line of the template.
+func WithTemplate(values map[string]interface{}) LoadOption {
+	return func(o *loadOptions) {
+		o.template = true
+		o.values = values
+	}
+}
+
+// WithEnvSubst is like envsubst, ${VAR} references in files are replaced by the values of
+// the environment variables before decoding them, unset variables are replaced by an empty
+// string. It is done after the rendering WithTemplate.
+func WithEnvSubst() LoadOption {
+	return func(o *loadOptions) {
+		o.envsubst = true
+	}
+}
+
+// render renders the given content of the given source with the given options.
+func render(source string, content []byte, o *loadOptions) ([]byte, error) {
+	if o.template {
+		tpl, err := template.New(source).Option("missingkey=error").Funcs(templateFuncs).Parse(string(content))
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err = tpl.Execute(&buf, o.values); err != nil {
+			return nil, err
+		}
+		content = buf.Bytes()
+	}
+	if o.envsubst {
+		content = envVarPattern.ReplaceAllFunc(content, func(ref []byte) []byte {
+			return []byte(os.Getenv(string(envVarPattern.FindSubmatch(ref)[1])))
+		})
+	}
+	return content, nil
+}
+
+var templateFuncs = template.FuncMap{
+	"env":     os.Getenv,
+	"default": defaultValue,
+	"b64enc": func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	},
+}
+
+// defaultValue returns def if the given value is empty, e.g. nil, "", 0 or an empty map.
+func defaultValue(def, value interface{}) interface{} {
+	if value == nil {
+		return def
+	}
+	if v := reflect.ValueOf(value); v.IsZero() || (v.Kind() == reflect.Map || v.Kind() == reflect.Slice) && v.Len() == 0 {
+		return def
+	}
+	return value
+}
@@ -0,0 +1,59 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestRender(t *testing.T) {
+	t.Setenv("KUBE_TEST_TAG", "1.25")
+
+	tests := []struct {
+		name     string
+		content  string
+		opts     []LoadOption
+		expected string
+		err      string
+	}{
+		{"none", "tag: {{ .tag }} ${KUBE_TEST_TAG}", nil, "tag: {{ .tag }} ${KUBE_TEST_TAG}", ""},
+		{"template:values", "tag: {{ .tag }}", []LoadOption{WithTemplate(map[string]interface{}{"tag": "1.24"})}, "tag: 1.24", ""},
+		{"template:env", `tag: {{ env "KUBE_TEST_TAG" }}`, []LoadOption{WithTemplate(nil)}, "tag: 1.25", ""},
+		{"template:default", `tag: {{ index . "tag" | default "latest" }}`, []LoadOption{WithTemplate(map[string]interface{}{})}, "tag: latest", ""},
+		{"template:default:nil", `tag: {{ index . "tag" | default "latest" }}`, []LoadOption{WithTemplate(nil)}, "tag: latest", ""},
+		{"template:default:set", `tag: {{ index . "tag" | default "latest" }}`, []LoadOption{WithTemplate(map[string]interface{}{"tag": "1.24"})}, "tag: 1.24", ""},
+		{"template:b64enc", `password: {{ .password | b64enc }}`, []LoadOption{WithTemplate(map[string]interface{}{"password": "kube"})}, "password: a3ViZQ==", ""},
+		{"template:error:parse", "a: 1\nb: {{ .tag \n", []LoadOption{WithTemplate(nil)}, "", "unclosed action started at test.yaml:2"},
+		{"template:error:missingkey", "a: 1\nimage: repo:{{ .tag }}", []LoadOption{WithTemplate(map[string]interface{}{"name": "kube"})}, "", `template: test.yaml:2:15: executing "test.yaml" at <.tag>: map has no entry for key "tag"`},
+		{"template:error:exec", "a: 1\nb: 2\nc: {{ .tag | b64enc }}", []LoadOption{WithTemplate(nil)}, "", "template: test.yaml:3:"},
+		{"envsubst", "tag: ${KUBE_TEST_TAG}, unset: '${KUBE_TEST_UNSET}', kept: $KUBE_TEST_TAG", []LoadOption{WithEnvSubst()}, "tag: 1.25, unset: '', kept: $KUBE_TEST_TAG", ""},
+		{"template:envsubst", "tag: {{ .tag }}-${KUBE_TEST_TAG}", []LoadOption{WithTemplate(map[string]interface{}{"tag": "1.24"}), WithEnvSubst()}, "tag: 1.24-1.25", ""},
+	}
+
+	for _, v := range tests {
+		t.Run(v.name, func(t *testing.T) {
+			got, err := render("test.yaml", []byte(v.content), newLoadOptions(v.opts...))
+			if v.err != "" {
+				require.ErrorContains(t, err, v.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, v.expected, string(got))
+		})
+	}
+}
+
+func TestLoadObjectsTemplate(t *testing.T) {
+	t.Setenv("HOME", "/home/kube")
+
+	objs, err := LoadObjects([]string{"testdata/content-template.yaml"},
+		WithTemplate(map[string]interface{}{"tag": "1.24"}), WithEnvSubst())
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+	cm, ok := objs[0].(*corev1.ConfigMap)
+	require.True(t, ok)
+	assert.Equal(t, "default", cm.Namespace)
+	assert.Equal(t, map[string]string{"image": "nginx:1.24", "home": "/home/kube"}, cm.Data)
+}
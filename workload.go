@@ -5,18 +5,21 @@ import (
 
 	v1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
-	"k8s.io/api/batch/v1beta1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // GetDeployment returns a Deployment with the given name.
 func (c *Client) GetDeployment(ctx context.Context, namespace, name string) (*v1.Deployment, error) {
-	return c.client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	return c.Deployments(namespace).Get(ctx, name)
 }
 
 // GetDeployments returns a DeploymentList.
 func (c *Client) GetDeployments(ctx context.Context, namespace string, label ...string) (*v1.DeploymentList, error) {
-	return c.client.AppsV1().Deployments(namespace).List(ctx, listOptions(label))
+	return c.Deployments(namespace).List(ctx, labelListOptions(label))
+}
+
+// GetDeploymentsWithOptions is like GetDeployments, but with the given ListOption.
+func (c *Client) GetDeploymentsWithOptions(ctx context.Context, namespace string, opts ...ListOption) (*v1.DeploymentList, error) {
+	return listWithOptions(ctx, c.Deployments(namespace).List, opts)
 }
 
 // CreateDeployment creates a new Deployment.
@@ -24,22 +27,27 @@ func (c *Client) CreateDeployment(ctx context.Context, deploy *v1.Deployment) (*
 	if len(deploy.Namespace) == 0 {
 		return nil, ErrorMissingNamespace
 	}
-	return c.client.AppsV1().Deployments(deploy.Namespace).Create(ctx, deploy, metav1.CreateOptions{})
+	return c.Deployments(deploy.Namespace).Create(ctx, deploy)
 }
 
 // DeleteDeployment deletes a Deployment.
-func (c *Client) DeleteDeployment(ctx context.Context, namespace, name string) error {
-	return c.client.AppsV1().Deployments(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+func (c *Client) DeleteDeployment(ctx context.Context, namespace, name string, opts ...DeleteOption) error {
+	return c.Deployments(namespace).Delete(ctx, name, opts...)
 }
 
 // GetDaemonSet returns a DaemonSet with given name.
 func (c *Client) GetDaemonSet(ctx context.Context, namespace, name string) (*v1.DaemonSet, error) {
-	return c.client.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	return c.DaemonSets(namespace).Get(ctx, name)
 }
 
 // GetDaemonSets returns a DaemonSetList.
 func (c *Client) GetDaemonSets(ctx context.Context, namespace string, label ...string) (*v1.DaemonSetList, error) {
-	return c.client.AppsV1().DaemonSets(namespace).List(ctx, listOptions(label))
+	return c.DaemonSets(namespace).List(ctx, labelListOptions(label))
+}
+
+// GetDaemonSetsWithOptions is like GetDaemonSets, but with the given ListOption.
+func (c *Client) GetDaemonSetsWithOptions(ctx context.Context, namespace string, opts ...ListOption) (*v1.DaemonSetList, error) {
+	return listWithOptions(ctx, c.DaemonSets(namespace).List, opts)
 }
 
 // CreateDaemonSet creates a new DaemonSet.
@@ -47,22 +55,27 @@ func (c *Client) CreateDaemonSet(ctx context.Context, dsData *v1.DaemonSet) (*v1
 	if len(dsData.Namespace) == 0 {
 		return nil, ErrorMissingNamespace
 	}
-	return c.client.AppsV1().DaemonSets(dsData.Namespace).Create(ctx, dsData, metav1.CreateOptions{})
+	return c.DaemonSets(dsData.Namespace).Create(ctx, dsData)
 }
 
 // DeleteDaemonSet deletes a DaemonSet.
-func (c *Client) DeleteDaemonSet(ctx context.Context, namespace, name string) error {
-	return c.client.AppsV1().DaemonSets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+func (c *Client) DeleteDaemonSet(ctx context.Context, namespace, name string, opts ...DeleteOption) error {
+	return c.DaemonSets(namespace).Delete(ctx, name, opts...)
 }
 
 // GetStatefulSet returns a StatefulSet with given name.
 func (c *Client) GetStatefulSet(ctx context.Context, namespace, name string) (*v1.StatefulSet, error) {
-	return c.client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	return c.StatefulSets(namespace).Get(ctx, name)
 }
 
 // GetStatefulSets returns a StatefulSetList.
 func (c *Client) GetStatefulSets(ctx context.Context, namespace string, label ...string) (*v1.StatefulSetList, error) {
-	return c.client.AppsV1().StatefulSets(namespace).List(ctx, listOptions(label))
+	return c.StatefulSets(namespace).List(ctx, labelListOptions(label))
+}
+
+// GetStatefulSetsWithOptions is like GetStatefulSets, but with the given ListOption.
+func (c *Client) GetStatefulSetsWithOptions(ctx context.Context, namespace string, opts ...ListOption) (*v1.StatefulSetList, error) {
+	return listWithOptions(ctx, c.StatefulSets(namespace).List, opts)
 }
 
 // CreateStatefulSet creates a new StatefulSet.
@@ -70,22 +83,27 @@ func (c *Client) CreateStatefulSet(ctx context.Context, statefulSet *v1.Stateful
 	if len(statefulSet.Namespace) == 0 {
 		return nil, ErrorMissingNamespace
 	}
-	return c.client.AppsV1().StatefulSets(statefulSet.Namespace).Create(ctx, statefulSet, metav1.CreateOptions{})
+	return c.StatefulSets(statefulSet.Namespace).Create(ctx, statefulSet)
 }
 
 // DeleteStatefulSet deletes a StatefulSet.
-func (c *Client) DeleteStatefulSet(ctx context.Context, namespace, name string) error {
-	return c.client.AppsV1().StatefulSets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+func (c *Client) DeleteStatefulSet(ctx context.Context, namespace, name string, opts ...DeleteOption) error {
+	return c.StatefulSets(namespace).Delete(ctx, name, opts...)
 }
 
 // GetJob returns a Job with given name.
 func (c *Client) GetJob(ctx context.Context, namespace, name string) (*batchv1.Job, error) {
-	return c.client.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	return c.Jobs(namespace).Get(ctx, name)
 }
 
 // GetJobs returns a JobList.
 func (c *Client) GetJobs(ctx context.Context, namespace string, label ...string) (*batchv1.JobList, error) {
-	return c.client.BatchV1().Jobs(namespace).List(ctx, listOptions(label))
+	return c.Jobs(namespace).List(ctx, labelListOptions(label))
+}
+
+// GetJobsWithOptions is like GetJobs, but with the given ListOption.
+func (c *Client) GetJobsWithOptions(ctx context.Context, namespace string, opts ...ListOption) (*batchv1.JobList, error) {
+	return listWithOptions(ctx, c.Jobs(namespace).List, opts)
 }
 
 // CreateJob creates a new Job.
@@ -93,33 +111,38 @@ func (c *Client) CreateJob(ctx context.Context, jobData *batchv1.Job) (*batchv1.
 	if len(jobData.Namespace) == 0 {
 		return nil, ErrorMissingNamespace
 	}
-	return c.client.BatchV1().Jobs(jobData.Namespace).Create(ctx, jobData, metav1.CreateOptions{})
+	return c.Jobs(jobData.Namespace).Create(ctx, jobData)
 }
 
 // DeleteJob deletes a Job.
-func (c *Client) DeleteJob(ctx context.Context, namespace, name string) error {
-	return c.client.BatchV1().Jobs(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+func (c *Client) DeleteJob(ctx context.Context, namespace, name string, opts ...DeleteOption) error {
+	return c.Jobs(namespace).Delete(ctx, name, opts...)
 }
 
-// GetCronJob returns a GetCronJob with given name.
-func (c *Client) GetCronJob(ctx context.Context, namespace, name string) (*v1beta1.CronJob, error) {
-	return c.client.BatchV1beta1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+// GetCronJob returns a CronJob with given name.
+func (c *Client) GetCronJob(ctx context.Context, namespace, name string) (*batchv1.CronJob, error) {
+	return c.CronJobs(namespace).Get(ctx, name)
 }
 
 // GetCronJobs returns a CronJobList.
-func (c *Client) GetCronJobs(ctx context.Context, namespace string, label ...string) (*v1beta1.CronJobList, error) {
-	return c.client.BatchV1beta1().CronJobs(namespace).List(ctx, listOptions(label))
+func (c *Client) GetCronJobs(ctx context.Context, namespace string, label ...string) (*batchv1.CronJobList, error) {
+	return c.CronJobs(namespace).List(ctx, labelListOptions(label))
+}
+
+// GetCronJobsWithOptions is like GetCronJobs, but with the given ListOption.
+func (c *Client) GetCronJobsWithOptions(ctx context.Context, namespace string, opts ...ListOption) (*batchv1.CronJobList, error) {
+	return listWithOptions(ctx, c.CronJobs(namespace).List, opts)
 }
 
 // CreateCronJob creates a new CronJob.
-func (c *Client) CreateCronJob(ctx context.Context, cronjob *v1beta1.CronJob) (*v1beta1.CronJob, error) {
+func (c *Client) CreateCronJob(ctx context.Context, cronjob *batchv1.CronJob) (*batchv1.CronJob, error) {
 	if len(cronjob.Namespace) == 0 {
 		return nil, ErrorMissingNamespace
 	}
-	return c.client.BatchV1beta1().CronJobs(cronjob.Namespace).Create(ctx, cronjob, metav1.CreateOptions{})
+	return c.CronJobs(cronjob.Namespace).Create(ctx, cronjob)
 }
 
 // DeleteCronJob deletes a CronJob.
-func (c *Client) DeleteCronJob(ctx context.Context, namespace, name string) error {
-	return c.client.BatchV1beta1().CronJobs(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+func (c *Client) DeleteCronJob(ctx context.Context, namespace, name string, opts ...DeleteOption) error {
+	return c.CronJobs(namespace).Delete(ctx, name, opts...)
 }
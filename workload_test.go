@@ -0,0 +1,42 @@
+package kube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCronJob(t *testing.T) {
+	cli := &Client{client: fake.NewClientset()}
+	ctx := context.TODO()
+
+	_, err := cli.CreateCronJob(ctx, &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "backup"}})
+	assert.ErrorIs(t, err, ErrorMissingNamespace)
+
+	_, err = cli.CreateCronJob(ctx, &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default", Labels: map[string]string{"app": "backup"}},
+		Spec:       batchv1.CronJobSpec{Schedule: "0 * * * *"},
+	})
+	require.NoError(t, err)
+
+	cronjob, err := cli.GetCronJob(ctx, "default", "backup")
+	require.NoError(t, err)
+	assert.Equal(t, "0 * * * *", cronjob.Spec.Schedule)
+
+	cronjobs, err := cli.GetCronJobs(ctx, "default", "app=backup")
+	require.NoError(t, err)
+	assert.Len(t, cronjobs.Items, 1)
+	cronjobs, err = cli.GetCronJobsWithOptions(ctx, "", WithLabelSelector("app=other"))
+	require.NoError(t, err)
+	assert.Empty(t, cronjobs.Items)
+
+	require.NoError(t, cli.DeleteCronJob(ctx, "default", "backup"))
+	_, err = cli.GetCronJob(ctx, "default", "backup")
+	assert.True(t, apierrors.IsNotFound(err))
+}